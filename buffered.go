@@ -0,0 +1,144 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package logging
+
+import (
+	"errors"
+	"io"
+	"sync"
+	"time"
+)
+
+var errWriterClosed = errors.New("writer is closed")
+
+// BufferedWriter coalesces writes to an io.Writer. Buffered data is flushed
+// once it reaches the size threshold or when interval has passed since the
+// first buffered write, whichever comes first. Errors from a timed flush are
+// returned by the next call to Write or Flush. Records written at a level by
+// loggers are coalesced only with records of the same level, so a
+// level-aware output such as LevelFilterWriter still sees their level.
+type BufferedWriter struct {
+	output   io.Writer
+	size     int
+	interval time.Duration
+
+	mu      sync.Mutex
+	buf     []byte
+	level   LogLevel
+	leveled bool
+	timer   *time.Timer
+	err     error
+	closed  bool
+}
+
+// NewBufferedWriter returns a BufferedWriter writing to output
+func NewBufferedWriter(output io.Writer, size int, interval time.Duration) *BufferedWriter {
+	return &BufferedWriter{
+		output:   output,
+		size:     size,
+		interval: interval,
+		buf:      make([]byte, 0, size),
+	}
+}
+
+// Write buffers data, flushing first if it doesn't fit into the buffer
+func (w *BufferedWriter) Write(data []byte) (int, error) {
+	return w.write(data, LogLevelDisabled, false)
+}
+
+func (w *BufferedWriter) writeAtLevel(level LogLevel, data []byte) (int, error) {
+	return w.write(data, level, true)
+}
+
+func (w *BufferedWriter) write(data []byte, level LogLevel, leveled bool) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return 0, errWriterClosed
+	}
+	if err := w.takeError(); err != nil {
+		return 0, err
+	}
+
+	if len(w.buf) > 0 && (len(w.buf)+len(data) > w.size || w.leveled != leveled || w.level != level) {
+		if err := w.flush(); err != nil {
+			return 0, err
+		}
+	}
+	w.buf = append(w.buf, data...)
+	w.level, w.leveled = level, leveled
+	if len(w.buf) >= w.size {
+		if err := w.flush(); err != nil {
+			return 0, err
+		}
+	} else if w.timer == nil {
+		w.timer = time.AfterFunc(w.interval, w.timedFlush)
+	}
+	return len(data), nil
+}
+
+// Flush writes all buffered data to the wrapped writer
+func (w *BufferedWriter) Flush() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if err := w.takeError(); err != nil {
+		return err
+	}
+	return w.flush()
+}
+
+// Close flushes the remaining data and stops the flush timer. It returns the
+// error of a previous timed flush, if any, or else the error of this flush.
+// It does not close the wrapped writer.
+func (w *BufferedWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+	err := w.flush()
+	if timedErr := w.takeError(); timedErr != nil {
+		return timedErr
+	}
+	return err
+}
+
+func (w *BufferedWriter) timedFlush() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.timer = nil
+	if err := w.flush(); err != nil && w.err == nil {
+		w.err = err
+	}
+}
+
+func (w *BufferedWriter) takeError() error {
+	err := w.err
+	w.err = nil
+	return err
+}
+
+func (w *BufferedWriter) flush() error {
+	if w.timer != nil {
+		w.timer.Stop()
+		w.timer = nil
+	}
+	if len(w.buf) == 0 {
+		return nil
+	}
+
+	var err error
+	if w.leveled {
+		_, err = writeAtLevel(w.output, w.level, w.buf)
+	} else {
+		_, err = w.output.Write(w.buf)
+	}
+	w.buf = w.buf[:0]
+	return err
+}
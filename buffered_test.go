@@ -0,0 +1,158 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package logging_test
+
+import (
+	"errors"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pion/logging"
+)
+
+func TestBufferedWriterSizeFlush(t *testing.T) {
+	var outBuf syncBuffer
+	counter := logging.NewCountingWriter(&outBuf)
+	writer := logging.NewBufferedWriter(counter, 40, time.Hour)
+
+	for i := 0; i < 3; i++ {
+		if _, err := io.WriteString(writer, "0123456789\n"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if outBuf.String() != "" {
+		t.Errorf("Expected nothing to be written before the size threshold, got %q", outBuf.String())
+	}
+
+	if _, err := io.WriteString(writer, "0123456789\n"); err != nil {
+		t.Fatal(err)
+	}
+	if counter.Lines() != 3 {
+		t.Errorf("Expected 3 lines after the size threshold, got %d", counter.Lines())
+	}
+
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if counter.Lines() != 4 {
+		t.Errorf("Expected Close to flush the remaining line, got %d lines", counter.Lines())
+	}
+	if _, err := io.WriteString(writer, "late\n"); err == nil {
+		t.Error("Expected an error writing after Close")
+	}
+	if err := writer.Close(); err != nil {
+		t.Errorf("Expected a second Close to succeed, got %v", err)
+	}
+}
+
+func TestBufferedWriterTimedFlush(t *testing.T) {
+	var outBuf syncBuffer
+	writer := logging.NewBufferedWriter(&outBuf, 1024, 5*time.Millisecond)
+	defer func() { _ = writer.Close() }()
+
+	if _, err := io.WriteString(writer, "timed\n"); err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for outBuf.String() != "timed\n" {
+		if time.Now().After(deadline) {
+			t.Fatal("Buffered data was not flushed after the interval")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestBufferedWriterFlush(t *testing.T) {
+	var outBuf syncBuffer
+	writer := logging.NewBufferedWriter(&outBuf, 1024, time.Hour)
+
+	if _, err := io.WriteString(writer, "explicit\n"); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if outBuf.String() != "explicit\n" {
+		t.Errorf("Expected Flush to write buffered data, got %q", outBuf.String())
+	}
+
+	failing := logging.NewBufferedWriter(failingWriter{}, 1024, time.Hour)
+	if _, err := io.WriteString(failing, "lost\n"); err != nil {
+		t.Fatal(err)
+	}
+	if err := failing.Flush(); !errors.Is(err, errWriteFailed) {
+		t.Errorf("Expected %v, got %v", errWriteFailed, err)
+	}
+}
+
+func TestBufferedWriterCloseAfterTimedFlushError(t *testing.T) {
+	var mu sync.Mutex
+	attempts := 0
+	writer := logging.NewBufferedWriter(writerFunc(func(data []byte) (int, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		attempts++
+		return 0, errWriteFailed
+	}), 1024, time.Millisecond)
+
+	if _, err := io.WriteString(writer, "lost\n"); err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		mu.Lock()
+		done := attempts > 0
+		mu.Unlock()
+		if done {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Buffered data was not flushed after the interval")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	if err := writer.Close(); !errors.Is(err, errWriteFailed) {
+		t.Errorf("Expected Close to report the timed flush error %v, got %v", errWriteFailed, err)
+	}
+}
+
+func benchmarkWrites(b *testing.B, wrap func(io.Writer) io.Writer) {
+	b.Helper()
+
+	counter := logging.NewCountingWriter(io.Discard)
+	var writes int
+	output := wrap(writerFunc(func(data []byte) (int, error) {
+		writes++
+		return counter.Write(data)
+	}))
+	logger := logging.NewDefaultLeveledLoggerForScope("bench", logging.LogLevelInfo, output)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		logger.Infof("packet %d", i)
+	}
+	if closer, ok := output.(io.Closer); ok {
+		_ = closer.Close()
+	}
+	b.ReportMetric(float64(writes)/float64(b.N), "writes/op")
+}
+
+func BenchmarkUnbufferedWrites(b *testing.B) {
+	benchmarkWrites(b, func(w io.Writer) io.Writer { return w })
+}
+
+func BenchmarkBufferedWrites(b *testing.B) {
+	benchmarkWrites(b, func(w io.Writer) io.Writer {
+		return logging.NewBufferedWriter(w, 64*1024, time.Second)
+	})
+}
+
+type writerFunc func([]byte) (int, error)
+
+func (f writerFunc) Write(data []byte) (int, error) {
+	return f(data)
+}
@@ -0,0 +1,49 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package logging
+
+import (
+	"io"
+	"os"
+)
+
+// Close stops the background goroutines started by the factory and closes
+// Writer if it implements io.Closer, unless it is os.Stdout or os.Stderr.
+// Loggers created by the factory discard their records afterwards. Calling
+// Close more than once is safe and returns the result of the first call.
+func (f *DefaultLoggerFactory) Close() error {
+	f.closeOnce.Do(func() {
+		f.mu.Lock()
+		stops := f.stops
+		f.stops = nil
+		writer := f.sharedWriter()
+		f.closed = true
+		f.mu.Unlock()
+
+		for _, stop := range stops {
+			stop()
+		}
+		f.closeErr = writer.close()
+	})
+	return f.closeErr
+}
+
+func (sw *syncWriter) close() error {
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+
+	sw.closed = true
+	if closer, ok := sw.output.(io.Closer); ok && sw.output != os.Stdout && sw.output != os.Stderr {
+		return closer.Close()
+	}
+	return flushWriter(sw.output)
+}
+
+// Close closes the wrapped factory if it holds resources
+func (f *SamplingLoggerFactory) Close() error {
+	if closer, ok := f.Factory.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package logging_test
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/pion/logging"
+)
+
+type closableBuffer struct {
+	bytes.Buffer
+	closed int
+}
+
+func (b *closableBuffer) Close() error {
+	b.closed++
+	return nil
+}
+
+func TestFactoryClose(t *testing.T) {
+	var outBuf closableBuffer
+	f := logging.NewSamplingLoggerFactory(&logging.DefaultLoggerFactory{
+		Writer:          &outBuf,
+		DefaultLogLevel: logging.LogLevelInfo,
+	}, nil)
+
+	logger := f.NewLogger("ice")
+	logger.Info("before close")
+
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if outBuf.closed != 1 {
+		t.Errorf("Expected the writer to be closed once, got %d", outBuf.closed)
+	}
+
+	written := outBuf.Len()
+	logger.Error("after close")
+	f.NewLogger("dtls").Error("after close")
+	if outBuf.Len() != written {
+		t.Errorf("Expected logging after Close to be a no-op, got %q", outBuf.String())
+	}
+}
+
+func TestFactoryCloseStdio(t *testing.T) {
+	f := logging.NewDefaultLoggerFactory()
+	stop := f.ReloadOnSignal(os.Interrupt)
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+	stop()
+
+	if _, err := os.Stderr.Stat(); err != nil {
+		t.Errorf("Expected os.Stderr to stay open, got %v", err)
+	}
+}
@@ -0,0 +1,104 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package logging
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+var (
+	errUnsupportedOutput = errors.New("unsupported log output")
+	errUnsupportedFormat = errors.New("unsupported log format")
+)
+
+// Config is the JSON document accepted by LoadConfig
+type Config struct {
+	// DefaultLevel is the level of scopes without an explicit level
+	DefaultLevel string `json:"defaultLevel"`
+	// ScopeLevels maps scopes to their level
+	ScopeLevels map[string]string `json:"scopeLevels"`
+	// Output is one of "stderr" (the default), "stdout" or "discard"
+	Output string `json:"output"`
+	// Format is the record format, "text" (the default) or "logfmt"
+	Format string `json:"format"`
+}
+
+// LoadConfig parses a JSON encoded Config from r and returns a factory
+// configured accordingly
+func LoadConfig(r io.Reader) (*DefaultLoggerFactory, error) {
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+
+	var config Config
+	if err := decoder.Decode(&config); err != nil {
+		return nil, fmt.Errorf("invalid logging config: %w", err)
+	}
+	return config.NewLoggerFactory()
+}
+
+// NewLoggerFactory returns a factory configured according to c
+func (c Config) NewLoggerFactory() (*DefaultLoggerFactory, error) {
+	factory := &DefaultLoggerFactory{
+		DefaultLogLevel: LogLevelError,
+		ScopeLevels:     make(map[string]LogLevel, len(c.ScopeLevels)),
+	}
+
+	if c.DefaultLevel != "" {
+		level, err := ParseLevel(c.DefaultLevel)
+		if err != nil {
+			return nil, fmt.Errorf("default level: %w", err)
+		}
+		factory.DefaultLogLevel = level
+	}
+
+	for scope, name := range c.ScopeLevels {
+		level, err := ParseLevel(name)
+		if err != nil {
+			return nil, fmt.Errorf("level of scope %q: %w", scope, err)
+		}
+		setScopeLevel(factory.ScopeLevels, scope, level)
+	}
+
+	switch c.Output {
+	case "", "stderr":
+		factory.Writer = os.Stderr
+	case "stdout":
+		factory.Writer = os.Stdout
+	case "discard":
+		factory.Writer = io.Discard
+	default:
+		return nil, fmt.Errorf("%w: %q", errUnsupportedOutput, c.Output)
+	}
+
+	switch c.Format {
+	case "", "text":
+	case "logfmt":
+		factory.Logfmt = true
+	default:
+		return nil, fmt.Errorf("%w: %q", errUnsupportedFormat, c.Format)
+	}
+
+	return factory, nil
+}
+
+// NewLoggerFactoryFromEnv returns a factory configured from the PION_LOG_*
+// variables, using the record format named by PION_LOG_FORMAT: "text", which
+// is also used when the variable is unset, or "logfmt".
+func NewLoggerFactoryFromEnv() (LoggerFactory, error) {
+	switch format := os.Getenv("PION_LOG_FORMAT"); strings.ToLower(format) {
+	case "", "text":
+		return NewDefaultLoggerFactory(), nil
+	case "logfmt":
+		factory := NewDefaultLoggerFactory()
+		factory.Logfmt = true
+		return factory, nil
+	default:
+		return nil, fmt.Errorf("%w: %q", errUnsupportedFormat, format)
+	}
+}
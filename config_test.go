@@ -0,0 +1,132 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package logging_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/pion/logging"
+)
+
+func TestLoadConfig(t *testing.T) {
+	f, err := logging.LoadConfig(strings.NewReader(`{
+		"defaultLevel": "warn",
+		"scopeLevels": {"ice": "Debug", "dtls": "TRACE"},
+		"output": "discard",
+		"format": "text"
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if f.DefaultLogLevel != logging.LogLevelWarn {
+		t.Errorf("Expected default level %s, got %s", logging.LogLevelWarn, f.DefaultLogLevel)
+	}
+	if f.ScopeLevels["ice"] != logging.LogLevelDebug || f.ScopeLevels["dtls"] != logging.LogLevelTrace {
+		t.Errorf("Unexpected scope levels %v", f.ScopeLevels)
+	}
+
+	logger, ok := f.NewLogger("ice").(*logging.DefaultLeveledLogger)
+	if !ok {
+		t.Fatal("Invalid logger type")
+	}
+	testDebugLevel(t, logger)
+}
+
+func TestLoadConfigLogfmt(t *testing.T) {
+	f, err := logging.LoadConfig(strings.NewReader(`{
+		"scopeLevels": {"ice": "info", "dtls": "disable"},
+		"format": "logfmt"
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.ScopeLevels["dtls"] != logging.LogLevelDisabled {
+		t.Errorf("Unexpected scope levels %v", f.ScopeLevels)
+	}
+
+	var outBuf bytes.Buffer
+	f.Writer = &outBuf
+	f.DisableTimestamp = true
+	logger, ok := f.NewLogger("ice").(*logging.DefaultLeveledLogger)
+	if !ok {
+		t.Fatal("Invalid logger type")
+	}
+	logger.InfoKV("gathering done", "candidates", 3)
+	if expected := `level=info scope=ice msg="gathering done" candidates=3` + "\n"; outBuf.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, outBuf.String())
+	}
+}
+
+func TestLoadConfigErrors(t *testing.T) {
+	for _, test := range []struct {
+		config string
+		err    string
+	}{
+		{`{"defaultLevel": "verbose"}`, `default level: invalid log level: "verbose"`},
+		{`{"scopeLevels": {"ice": "loud"}}`, `level of scope "ice": invalid log level: "loud"`},
+		{`{"output": "syslog"}`, `unsupported log output: "syslog"`},
+		{`{"format": "json"}`, `unsupported log format: "json"`},
+		{`{"level": "debug"}`, `invalid logging config: json: unknown field "level"`},
+		{`{`, `invalid logging config: unexpected EOF`},
+	} {
+		if _, err := logging.LoadConfig(strings.NewReader(test.config)); err == nil || err.Error() != test.err {
+			t.Errorf("Expected error %q for %s, got %v", test.err, test.config, err)
+		}
+	}
+}
+
+func TestNewLoggerFactoryFromEnv(t *testing.T) {
+	for _, format := range []string{"", "text", "TEXT"} {
+		t.Setenv("PION_LOG_FORMAT", format)
+		t.Setenv("PION_LOG_INFO", "ice")
+
+		f, err := logging.NewLoggerFactoryFromEnv()
+		if err != nil {
+			t.Fatal(err)
+		}
+		factory, ok := f.(*logging.DefaultLoggerFactory)
+		if !ok {
+			t.Fatalf("Unexpected factory type %T", f)
+		}
+
+		var outBuf bytes.Buffer
+		factory.Writer = &outBuf
+		factory.DisableTimestamp = true
+		f.NewLogger("ice").Info("sample")
+		if expected := "ice INFO: sample\n"; outBuf.String() != expected {
+			t.Errorf("Expected %q, got %q", expected, outBuf.String())
+		}
+	}
+
+	for _, format := range []string{"logfmt", "LOGFMT"} {
+		t.Setenv("PION_LOG_FORMAT", format)
+
+		f, err := logging.NewLoggerFactoryFromEnv()
+		if err != nil {
+			t.Fatal(err)
+		}
+		factory, ok := f.(*logging.DefaultLoggerFactory)
+		if !ok {
+			t.Fatalf("Unexpected factory type %T", f)
+		}
+
+		var outBuf bytes.Buffer
+		factory.Writer = &outBuf
+		factory.DisableTimestamp = true
+		f.NewLogger("ice").Info("sample")
+		if expected := "level=info scope=ice msg=sample\n"; outBuf.String() != expected {
+			t.Errorf("Expected %q, got %q", expected, outBuf.String())
+		}
+	}
+
+	for _, format := range []string{"json", "xml"} {
+		t.Setenv("PION_LOG_FORMAT", format)
+		if _, err := logging.NewLoggerFactoryFromEnv(); err == nil {
+			t.Errorf("Expected an error for format %q", format)
+		}
+	}
+}
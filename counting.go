@@ -0,0 +1,51 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package logging
+
+import (
+	"bytes"
+	"io"
+	"sync/atomic"
+)
+
+// CountingWriter wraps an io.Writer and counts the bytes and lines written
+// through it, e.g. to graph log volume
+type CountingWriter struct {
+	output io.Writer
+	bytes  uint64
+	lines  uint64
+}
+
+// NewCountingWriter returns a CountingWriter writing to output
+func NewCountingWriter(output io.Writer) *CountingWriter {
+	return &CountingWriter{output: output}
+}
+
+// Write writes data to the wrapped writer and counts what was written
+func (cw *CountingWriter) Write(data []byte) (int, error) {
+	n, err := cw.output.Write(data)
+	cw.count(data[:n])
+	return n, err
+}
+
+func (cw *CountingWriter) writeAtLevel(level LogLevel, data []byte) (int, error) {
+	n, err := writeAtLevel(cw.output, level, data)
+	cw.count(data[:n])
+	return n, err
+}
+
+func (cw *CountingWriter) count(written []byte) {
+	atomic.AddUint64(&cw.bytes, uint64(len(written)))
+	atomic.AddUint64(&cw.lines, uint64(bytes.Count(written, []byte{'\n'})))
+}
+
+// Bytes returns the number of bytes written
+func (cw *CountingWriter) Bytes() uint64 {
+	return atomic.LoadUint64(&cw.bytes)
+}
+
+// Lines returns the number of newline terminated lines written
+func (cw *CountingWriter) Lines() uint64 {
+	return atomic.LoadUint64(&cw.lines)
+}
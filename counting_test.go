@@ -0,0 +1,44 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package logging_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/pion/logging"
+)
+
+func TestCountingWriter(t *testing.T) {
+	var outBuf bytes.Buffer
+	writer := logging.NewCountingWriter(&outBuf)
+
+	f := logging.DefaultLoggerFactory{Writer: writer, DefaultLogLevel: logging.LogLevelInfo}
+	logger := f.NewLogger("ice")
+	logger.Info("first")
+	logger.Warnf("second %d", 2)
+	logger.Debug("filtered")
+
+	if lines := writer.Lines(); lines != 2 {
+		t.Errorf("Expected 2 lines, got %d", lines)
+	}
+	if n := writer.Bytes(); n != uint64(outBuf.Len()) {
+		t.Errorf("Expected %d bytes, got %d", outBuf.Len(), n)
+	}
+
+	if _, err := writer.Write([]byte("a\nb\nc")); err != nil {
+		t.Fatal(err)
+	}
+	if lines := writer.Lines(); lines != 4 {
+		t.Errorf("Expected 4 lines, got %d", lines)
+	}
+
+	failing := logging.NewCountingWriter(failingWriter{})
+	if _, err := failing.Write([]byte("lost\n")); err == nil {
+		t.Error("Expected the write error to be returned")
+	}
+	if failing.Bytes() != 0 || failing.Lines() != 0 {
+		t.Error("Expected failed writes not to be counted")
+	}
+}
@@ -0,0 +1,60 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package logging
+
+// Flusher is implemented by writers, loggers and factories that buffer
+// records, such as BufferedWriter
+type Flusher interface {
+	Flush() error
+}
+
+// flushWriter flushes w if it buffers records
+func flushWriter(w interface{}) error {
+	if flusher, ok := w.(Flusher); ok {
+		return flusher.Flush()
+	}
+	return nil
+}
+
+func (lw *loggerWriter) Flush() error {
+	lw.Lock()
+	defer lw.Unlock()
+	return flushWriter(lw.output)
+}
+
+func (sw *syncWriter) Flush() error {
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+	return flushWriter(sw.output)
+}
+
+// Flush flushes the logger's output if it buffers records
+func (ll *DefaultLeveledLogger) Flush() error {
+	return ll.writer.Flush()
+}
+
+// Flush flushes Writer if it buffers records. Loggers created by the factory
+// all write to it.
+func (f *DefaultLoggerFactory) Flush() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.writer != nil {
+		return f.writer.Flush()
+	}
+	return flushWriter(f.Writer)
+}
+
+// Flush flushes the wrapped factory if it buffers records
+func (f *SamplingLoggerFactory) Flush() error {
+	return flushWriter(f.Factory)
+}
+
+// Flush flushes the wrapped logger if it buffers records
+func (l *samplingLogger) Flush() error {
+	return flushWriter(l.logger)
+}
+
+// Flush does nothing
+func (NoopLogger) Flush() error { return nil }
@@ -0,0 +1,66 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package logging_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pion/logging"
+)
+
+func TestFlush(t *testing.T) {
+	var outBuf syncBuffer
+	buffered := logging.NewBufferedWriter(&outBuf, 1024, time.Hour)
+	f := logging.NewSamplingLoggerFactory(&logging.DefaultLoggerFactory{
+		Writer:          buffered,
+		DefaultLogLevel: logging.LogLevelInfo,
+	}, nil)
+
+	logger := f.NewLogger("ice")
+	logger.Info("buffered")
+	if outBuf.String() != "" {
+		t.Fatalf("Expected nothing to be written before Flush, got %q", outBuf.String())
+	}
+
+	flusher, ok := logger.(logging.Flusher)
+	if !ok {
+		t.Fatal("Expected the logger to implement Flusher")
+	}
+	if err := flusher.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(outBuf.String(), "buffered\n") {
+		t.Errorf("Expected the record after Flush, got %q", outBuf.String())
+	}
+
+	logger.Warn("second")
+	if err := f.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(outBuf.String(), "second\n") {
+		t.Errorf("Expected the factory to flush the record, got %q", outBuf.String())
+	}
+}
+
+func TestFlushPlainWriter(t *testing.T) {
+	var outBuf bytes.Buffer
+	logger := logging.NewDefaultLeveledLoggerForScope("ice", logging.LogLevelInfo, &outBuf)
+	if err := logger.Flush(); err != nil {
+		t.Errorf("Expected flushing a plain writer to be a no-op, got %v", err)
+	}
+	if err := (logging.NoopLogger{}).Flush(); err != nil {
+		t.Error(err)
+	}
+
+	failing := logging.NewBufferedWriter(failingWriter{}, 1024, time.Hour)
+	f := logging.DefaultLoggerFactory{Writer: failing, DefaultLogLevel: logging.LogLevelInfo}
+	f.NewLogger("ice").Info("lost")
+	if err := f.Flush(); !errors.Is(err, errWriteFailed) {
+		t.Errorf("Expected %v, got %v", errWriteFailed, err)
+	}
+}
@@ -0,0 +1,67 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package logging
+
+import (
+	"fmt"
+	"strings"
+)
+
+// formatErrorKey is the field emitted in strict mode when the arguments of a
+// formatting method don't match its format string
+const formatErrorKey = "!LOGGING_FORMAT_ERROR"
+
+// WithStrictFormat is a chainable configuration function which checks that
+// the formatting methods get as many arguments as their format has verbs.
+// On a mismatch the raw format and arguments are emitted along with a
+// !LOGGING_FORMAT_ERROR field instead of fmt's mangled output.
+func (ll *DefaultLeveledLogger) WithStrictFormat(enabled bool) *DefaultLeveledLogger {
+	ll.strictFormat = enabled
+	return ll
+}
+
+// sprintf formats like fmt.Sprintf, reporting mismatched arguments in strict mode
+func (ll *DefaultLeveledLogger) sprintf(format string, args []interface{}) string {
+	if !ll.strictFormat {
+		return fmt.Sprintf(format, args...)
+	}
+
+	verbs, ok := countVerbs(format)
+	if !ok || verbs == len(args) {
+		return fmt.Sprintf(format, args...)
+	}
+
+	var b strings.Builder
+	b.WriteString(format)
+	appendField(&b, formatErrorKey, fmt.Sprintf("%d verbs, %d args", verbs, len(args)))
+	if len(args) > 0 {
+		appendField(&b, "args", fmt.Sprint(args...))
+	}
+	return b.String()
+}
+
+// countVerbs returns the number of arguments consumed by format. It reports
+// false for formats using explicit argument indexes, which it can't check.
+func countVerbs(format string) (int, bool) {
+	verbs := 0
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			continue
+		}
+
+		// skip flags, width and precision, where '*' consumes an argument
+		for i++; i < len(format) && strings.IndexByte("+-# 0123456789.*[", format[i]) >= 0; i++ {
+			switch format[i] {
+			case '[':
+				return 0, false
+			case '*':
+				verbs++
+			}
+		}
+		if i < len(format) && format[i] != '%' {
+			verbs++
+		}
+	}
+	return verbs, true
+}
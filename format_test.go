@@ -0,0 +1,62 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package logging_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/pion/logging"
+)
+
+func TestWithStrictFormat(t *testing.T) {
+	var outBuf bytes.Buffer
+	logger := logging.
+		NewDefaultLeveledLoggerForScope("testStrict", logging.LogLevelInfo, &outBuf).
+		WithTimestamp("")
+
+	for _, test := range []struct {
+		format  string
+		args    []interface{}
+		strict  string
+		lenient string
+	}{
+		{
+			"no args here", []interface{}{"extra"},
+			`no args here !LOGGING_FORMAT_ERROR="0 verbs, 1 args" args=extra`,
+			"no args here%!(EXTRA string=extra)",
+		},
+		{
+			"%s and %d", []interface{}{"one"},
+			`%s and %d !LOGGING_FORMAT_ERROR="2 verbs, 1 args" args=one`,
+			"one and %!d(MISSING)",
+		},
+		{"100%% of %-5s|%*d|", []interface{}{"x", 3, 7}, "100% of x    |  7|", "100% of x    |  7|"},
+		{"%[2]s %[1]s", []interface{}{"a", "b"}, "b a", "b a"},
+		{
+			"failed: %v", nil,
+			`failed: %v !LOGGING_FORMAT_ERROR="1 verbs, 0 args"`,
+			"failed: %!v(MISSING)",
+		},
+	} {
+		for _, strict := range []bool{true, false} {
+			outBuf.Reset()
+			logger.WithStrictFormat(strict).Infof(test.format, test.args...)
+
+			expected := "testStrict INFO: " + test.lenient + "\n"
+			if strict {
+				expected = "testStrict INFO: " + test.strict + "\n"
+			}
+			if outBuf.String() != expected {
+				t.Errorf("Expected %q, got %q", expected, outBuf.String())
+			}
+		}
+	}
+
+	outBuf.Reset()
+	logger.WithStrictFormat(true).Info("100% done")
+	if expected := "testStrict INFO: 100% done\n"; outBuf.String() != expected {
+		t.Errorf("Expected plain messages not to be formatted, got %q", outBuf.String())
+	}
+}
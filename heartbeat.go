@@ -0,0 +1,73 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package logging
+
+import (
+	"sync"
+	"time"
+)
+
+// StartHeartbeat emits a heartbeat message at the supplied level every
+// interval until the returned stop function is called. The message is built
+// by calling msg on every tick, so it may carry dynamic state. stop blocks
+// until the heartbeat goroutine has exited and is safe to call more than once.
+// A non-positive interval starts no heartbeat and returns a no-op stop.
+func StartHeartbeat(logger LeveledLogger, level LogLevel, interval time.Duration, msg func() string) (stop func()) {
+	if interval <= 0 {
+		return func() {}
+	}
+
+	done := make(chan struct{})
+	exited := make(chan struct{})
+
+	go func() {
+		defer close(exited)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if e, ok := logger.(levelEnabler); ok && !e.Enabled(level) {
+					continue
+				}
+				logAtLevel(logger, level, msg())
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			close(done)
+		})
+		<-exited
+	}
+}
+
+// levelEnabler is implemented by loggers that can report whether a level is
+// emitted without formatting a message
+type levelEnabler interface {
+	Enabled(level LogLevel) bool
+}
+
+// logAtLevel emits msg on logger using the method matching level
+func logAtLevel(logger LeveledLogger, level LogLevel, msg string) {
+	switch level {
+	case LogLevelTrace:
+		logger.Trace(msg)
+	case LogLevelDebug:
+		logger.Debug(msg)
+	case LogLevelInfo:
+		logger.Info(msg)
+	case LogLevelWarn:
+		logger.Warn(msg)
+	case LogLevelError:
+		logger.Error(msg)
+	default:
+	}
+}
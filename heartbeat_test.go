@@ -0,0 +1,106 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package logging_test
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pion/logging"
+)
+
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestStartHeartbeat(t *testing.T) {
+	var outBuf syncBuffer
+	logger := logging.NewDefaultLeveledLoggerForScope("heartbeat", logging.LogLevelInfo, &outBuf)
+
+	var mu sync.Mutex
+	beats := 0
+	stop := logging.StartHeartbeat(logger, logging.LogLevelInfo, time.Millisecond, func() string {
+		mu.Lock()
+		defer mu.Unlock()
+		beats++
+		return fmt.Sprintf("alive beat=%d", beats)
+	})
+
+	deadline := time.Now().Add(5 * time.Second)
+	for strings.Count(outBuf.String(), "alive") < 3 {
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected at least 3 heartbeats, got %q", outBuf.String())
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	stop()
+	stop()
+
+	after := outBuf.String()
+	time.Sleep(10 * time.Millisecond)
+	if outBuf.String() != after {
+		t.Error("Heartbeat was logged after stop returned")
+	}
+	if !strings.Contains(after, "beat=1") {
+		t.Errorf("Expected to find dynamic message in %q", after)
+	}
+}
+
+func TestStartHeartbeatFiltered(t *testing.T) {
+	var outBuf syncBuffer
+	logger := logging.NewDefaultLeveledLoggerForScope("heartbeat", logging.LogLevelWarn, &outBuf)
+
+	var mu sync.Mutex
+	calls := 0
+	stop := logging.StartHeartbeat(logger, logging.LogLevelDebug, time.Millisecond, func() string {
+		mu.Lock()
+		defer mu.Unlock()
+		calls++
+		return "alive"
+	})
+	time.Sleep(10 * time.Millisecond)
+	stop()
+
+	if out := outBuf.String(); out != "" {
+		t.Errorf("Debug heartbeat was logged at Warn level: %q", out)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if calls != 0 {
+		t.Errorf("Expected message not to be built for a disabled level, built %d times", calls)
+	}
+}
+
+func TestStartHeartbeatInvalidInterval(t *testing.T) {
+	var outBuf syncBuffer
+	logger := logging.NewDefaultLeveledLoggerForScope("heartbeat", logging.LogLevelInfo, &outBuf)
+
+	for _, interval := range []time.Duration{0, -time.Second} {
+		stop := logging.StartHeartbeat(logger, logging.LogLevelInfo, interval, func() string {
+			return "alive"
+		})
+		stop()
+	}
+	if out := outBuf.String(); out != "" {
+		t.Errorf("Expected no heartbeat for a non-positive interval, got %q", out)
+	}
+}
@@ -0,0 +1,150 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package logging
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// badKey is used for values in a key/value list that have no string key
+const badKey = "!BADKEY"
+
+// parseKV pairs up the keys and values in kv. Values without a string key
+// are put under !BADKEY, like log/slog does.
+func parseKV(kv []interface{}) []Field {
+	fields := make([]Field, 0, (len(kv)+1)/2)
+	for i := 0; i < len(kv); i++ {
+		key, isString := kv[i].(string)
+		if !isString || i+1 == len(kv) {
+			fields = append(fields, Field{badKey, resolveValue(kv[i])})
+			continue
+		}
+		fields = append(fields, Field{key, resolveValue(kv[i+1])})
+		i++
+	}
+	return fields
+}
+
+// resolveValue calls a func() interface{} value and returns its result, so
+// that expensive values are only computed for enabled records
+func resolveValue(value interface{}) interface{} {
+	if fn, ok := value.(func() interface{}); ok {
+		return fn()
+	}
+	return value
+}
+
+// formatKV renders the key/value pairs in kv as key=value fields, each
+// preceded by a space, in call order or sorted by key
+func formatKV(kv []interface{}, sorted bool) string {
+	fields := parseKV(kv)
+	if sorted {
+		sort.SliceStable(fields, func(i, j int) bool {
+			return fields[i].Key < fields[j].Key
+		})
+	}
+
+	var b strings.Builder
+	for _, f := range fields {
+		b.WriteByte(' ')
+		writeField(&b, f.Key, f.Value)
+	}
+	return b.String()
+}
+
+func appendField(b *strings.Builder, key string, value interface{}) {
+	if b.Len() > 0 {
+		b.WriteByte(' ')
+	}
+	writeField(b, key, value)
+}
+
+func writeField(b *strings.Builder, key string, value interface{}) {
+	b.WriteString(key)
+	b.WriteByte('=')
+
+	str := fmt.Sprint(value)
+	if str == "" || strings.ContainsAny(str, " =\"\t\r\n") {
+		str = strconv.Quote(str)
+	}
+	b.WriteString(str)
+}
+
+// WithGlobalFields is a chainable configuration function which adds
+// key/value pairs emitted on every record, after the message and before
+// the fields of the KV methods
+func (ll *DefaultLeveledLogger) WithGlobalFields(kv ...interface{}) *DefaultLeveledLogger {
+	ll.fields += formatKV(kv, false)
+	return ll
+}
+
+// WithSortedFields is a chainable configuration function which makes the KV
+// methods emit their fields sorted by key instead of in call order
+func (ll *DefaultLeveledLogger) WithSortedFields(enabled bool) *DefaultLeveledLogger {
+	ll.sortFields = enabled
+	return ll
+}
+
+// TraceKV emits msg followed by the key/value pairs in kv if the logger is at
+// or below LogLevelTrace
+func (ll *DefaultLeveledLogger) TraceKV(msg string, kv ...interface{}) {
+	ll.logKV(ll.trace, LogLevelTrace, msg, kv)
+}
+
+// DebugKV emits msg followed by the key/value pairs in kv if the logger is at
+// or below LogLevelDebug
+func (ll *DefaultLeveledLogger) DebugKV(msg string, kv ...interface{}) {
+	ll.logKV(ll.debug, LogLevelDebug, msg, kv)
+}
+
+// InfoKV emits msg followed by the key/value pairs in kv if the logger is at
+// or below LogLevelInfo
+func (ll *DefaultLeveledLogger) InfoKV(msg string, kv ...interface{}) {
+	ll.logKV(ll.info, LogLevelInfo, msg, kv)
+}
+
+// WarnKV emits msg followed by the key/value pairs in kv if the logger is at
+// or below LogLevelWarn
+func (ll *DefaultLeveledLogger) WarnKV(msg string, kv ...interface{}) {
+	ll.logKV(ll.warn, LogLevelWarn, msg, kv)
+}
+
+// ErrorKV emits msg followed by the key/value pairs in kv if the logger is at
+// or below LogLevelError
+func (ll *DefaultLeveledLogger) ErrorKV(msg string, kv ...interface{}) {
+	ll.logKV(ll.err, LogLevelError, msg, kv)
+}
+
+// LogFields emits msg followed by fields at the given level, with keys in
+// sorted order. Nothing is rendered when the level is disabled.
+func (ll *DefaultLeveledLogger) LogFields(level LogLevel, msg string, fields map[string]interface{}) {
+	if logger := ll.loggerForLevel(level); logger != nil && ll.Enabled(level) {
+		ll.logFields(logger, level, msg, fields)
+	}
+}
+
+func (ll *DefaultLeveledLogger) logFields(logger *log.Logger, level LogLevel, msg string, fields map[string]interface{}) {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for _, key := range keys {
+		b.WriteByte(' ')
+		writeField(&b, key, resolveValue(fields[key]))
+	}
+	ll.output(logger, level, msg, b.String(), 0)
+}
+
+func (ll *DefaultLeveledLogger) logKV(logger *log.Logger, level LogLevel, msg string, kv []interface{}) {
+	if ll.Enabled(level) {
+		ll.output(logger, level, msg, formatKV(kv, ll.sortFields), 0)
+	}
+}
@@ -0,0 +1,218 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package logging_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pion/logging"
+)
+
+func TestKV(t *testing.T) {
+	var outBuf bytes.Buffer
+	logger := logging.
+		NewDefaultLeveledLoggerForScope("testKV", logging.LogLevelTrace, &outBuf).
+		WithTimestamp("")
+
+	for _, test := range []struct {
+		log      func(string, ...interface{})
+		kv       []interface{}
+		expected string
+	}{
+		{logger.InfoKV, []interface{}{"candidate", "host", "port", 5000}, "testKV INFO: gathered candidate=host port=5000\n"},
+		{logger.WarnKV, []interface{}{"reason", "timed out", "err", errWriteFailed}, "testKV WARNING: gathered reason=\"timed out\" err=\"write failed\"\n"},
+		{logger.ErrorKV, []interface{}{"empty", ""}, "testKV ERROR: gathered empty=\"\"\n"},
+		{logger.InfoKV, []interface{}{"candidate", "host", "dangling"}, "testKV INFO: gathered candidate=host !BADKEY=dangling\n"},
+		{logger.InfoKV, []interface{}{42, "port", 5000}, "testKV INFO: gathered !BADKEY=42 port=5000\n"},
+		{logger.InfoKV, nil, "testKV INFO: gathered\n"},
+	} {
+		outBuf.Reset()
+		test.log("gathered", test.kv...)
+		if outBuf.String() != test.expected {
+			t.Errorf("Expected %q, got %q", test.expected, outBuf.String())
+		}
+	}
+
+	outBuf.Reset()
+	logger.DebugKV("debug", "k", "v")
+	logger.TraceKV("trace", "k", "v")
+	if expected := "trace k=v\n"; !bytes.HasSuffix(outBuf.Bytes(), []byte(expected)) {
+		t.Errorf("Expected %q to end with %q", outBuf.String(), expected)
+	}
+
+	outBuf.Reset()
+	logger.SetLevel(logging.LogLevelWarn)
+	logger.InfoKV("filtered", "k", "v")
+	logger.DebugKV("filtered", "k", "v")
+	logger.TraceKV("filtered", "k", "v")
+	if outBuf.Len() > 0 {
+		t.Errorf("Records were logged when they shouldn't have been: %q", outBuf.String())
+	}
+}
+
+type countingStringer struct {
+	calls int
+}
+
+func (s *countingStringer) String() string {
+	s.calls++
+	return "rendered"
+}
+
+func TestLogFields(t *testing.T) {
+	var outBuf bytes.Buffer
+	logger := logging.
+		NewDefaultLeveledLoggerForScope("testLogFields", logging.LogLevelInfo, &outBuf).
+		WithTimestamp("")
+
+	stringer := &countingStringer{}
+	logger.LogFields(logging.LogLevelWarn, "connected", map[string]interface{}{
+		"port":     5000,
+		"rtt":      1500 * time.Microsecond,
+		"host":     "example.com",
+		"relay":    false,
+		"loss":     0.25,
+		"err":      errWriteFailed,
+		"stringer": stringer,
+	})
+	expected := "testLogFields WARNING: connected err=\"write failed\" host=example.com loss=0.25 " +
+		"port=5000 relay=false rtt=1.5ms stringer=rendered\n"
+	if outBuf.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, outBuf.String())
+	}
+
+	outBuf.Reset()
+	logger.LogFields(logging.LogLevelDebug, "filtered", map[string]interface{}{"stringer": stringer})
+	if outBuf.Len() > 0 || stringer.calls != 1 {
+		t.Errorf("Expected disabled level to skip rendering, got %q and %d calls", outBuf.String(), stringer.calls)
+	}
+}
+
+func TestKVStringerDeferred(t *testing.T) {
+	var outBuf bytes.Buffer
+	logger := logging.
+		NewDefaultLeveledLoggerForScope("testStringer", logging.LogLevelInfo, &outBuf).
+		WithTimestamp("")
+
+	stringer := &countingStringer{}
+	logger.DebugKV("filtered", "packet", stringer)
+	logger.TraceKV("filtered", "packet", stringer)
+	if stringer.calls != 0 {
+		t.Errorf("Expected String not to be called for disabled levels, got %d calls", stringer.calls)
+	}
+
+	logger.InfoKV("received", "packet", stringer)
+	if stringer.calls != 1 {
+		t.Errorf("Expected String to be called once, got %d calls", stringer.calls)
+	}
+	if expected := "testStringer INFO: received packet=rendered\n"; outBuf.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, outBuf.String())
+	}
+}
+
+func TestKVFuncDeferred(t *testing.T) {
+	var outBuf bytes.Buffer
+	logger := logging.
+		NewDefaultLeveledLoggerForScope("testFunc", logging.LogLevelInfo, &outBuf).
+		WithTimestamp("")
+
+	calls := 0
+	summary := func() interface{} {
+		calls++
+		return "3 candidates"
+	}
+	logger.DebugKV("filtered", "summary", summary)
+	if calls != 0 {
+		t.Errorf("Expected the func not to run for disabled levels, got %d calls", calls)
+	}
+
+	logger.InfoKV("gathered", "summary", summary)
+	if calls != 1 {
+		t.Errorf("Expected the func to run once, got %d calls", calls)
+	}
+	if expected := "testFunc INFO: gathered summary=\"3 candidates\"\n"; outBuf.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, outBuf.String())
+	}
+}
+
+func TestWithSortedFields(t *testing.T) {
+	var outBuf bytes.Buffer
+	logger := logging.
+		NewDefaultLeveledLoggerForScope("testSorted", logging.LogLevelInfo, &outBuf).
+		WithTimestamp("")
+
+	logger.InfoKV("msg", "zeta", 1, "alpha", 2, "mid", 3)
+	logger.WithSortedFields(true)
+	logger.InfoKV("msg", "zeta", 1, "alpha", 2, "mid", 3, "dangling")
+	logger.WithScope("child").InfoKV("msg", "b", 1, "a", 2)
+
+	expected := "testSorted INFO: msg zeta=1 alpha=2 mid=3\n" +
+		"testSorted INFO: msg !BADKEY=dangling alpha=2 mid=3 zeta=1\n" +
+		"testSorted.child INFO: msg a=2 b=1\n"
+	if outBuf.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, outBuf.String())
+	}
+}
+
+func TestGlobalFields(t *testing.T) {
+	var outBuf bytes.Buffer
+	logger := logging.
+		NewDefaultLeveledLoggerForScope("testGlobalFields", logging.LogLevelTrace, &outBuf).
+		WithTimestamp("").
+		WithCaller(false, 0).
+		WithGlobalFields("node", "edge-1").
+		WithGlobalFields("region", "eu west")
+
+	logger.Debug("plain")
+	logger.Warnf("formatted %d", 1)
+	logger.ErrorKV("kv", "node", "override")
+	logger.LogFields(logging.LogLevelInfo, "fields", map[string]interface{}{"port": 5000})
+	logger.WithScope("child").Trace("scoped")
+
+	expected := "testGlobalFields DEBUG: plain node=edge-1 region=\"eu west\"\n" +
+		"testGlobalFields WARNING: formatted 1 node=edge-1 region=\"eu west\"\n" +
+		"testGlobalFields ERROR: kv node=edge-1 region=\"eu west\" node=override\n" +
+		"testGlobalFields INFO: fields node=edge-1 region=\"eu west\" port=5000\n" +
+		"testGlobalFields.child TRACE: scoped node=edge-1 region=\"eu west\"\n"
+	if outBuf.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, outBuf.String())
+	}
+}
+
+func TestGlobalFieldsFactory(t *testing.T) {
+	var outBuf bytes.Buffer
+	f := logging.New(
+		logging.WithWriter(&outBuf),
+		logging.WithLevel(logging.LogLevelInfo),
+		logging.WithTimestampLayout(""),
+		logging.WithGlobalFields("node", "edge-1"),
+	)
+
+	f.NewLogger("ice").Info("connected")
+	f.NewLogger("dtls").Error("handshake failed")
+
+	expected := "ice INFO: connected node=edge-1\ndtls ERROR: handshake failed node=edge-1\n"
+	if outBuf.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, outBuf.String())
+	}
+}
+
+func TestKVCaller(t *testing.T) {
+	var outBuf bytes.Buffer
+	logger := logging.
+		NewDefaultLeveledLoggerForScope("testKVCaller", logging.LogLevelInfo, &outBuf).
+		WithTimestamp("").
+		WithCaller(true, 0)
+
+	logger.InfoKV("kv", "k", "v")
+	logger.LogFields(logging.LogLevelInfo, "fields", nil)
+	for _, line := range strings.Split(strings.TrimSpace(outBuf.String()), "\n") {
+		if !strings.Contains(line, "kv_test.go:") {
+			t.Errorf("Expected the call site in %q", line)
+		}
+	}
+}
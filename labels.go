@@ -0,0 +1,67 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package logging
+
+import "log"
+
+// ShortLevelLabels returns three letter level labels, such as INF and WRN,
+// for use with WithLevelLabels
+func ShortLevelLabels() map[LogLevel]string {
+	return map[LogLevel]string{
+		LogLevelTrace: "TRC",
+		LogLevelDebug: "DBG",
+		LogLevelInfo:  "INF",
+		LogLevelWarn:  "WRN",
+		LogLevelError: "ERR",
+	}
+}
+
+// levelLabel returns the label of level in labels, falling back to the
+// default long labels
+func levelLabel(labels map[LogLevel]string, level LogLevel) string {
+	if label, found := labels[level]; found {
+		return label
+	}
+
+	switch level {
+	case LogLevelTrace:
+		return "TRACE"
+	case LogLevelDebug:
+		return "DEBUG"
+	case LogLevelInfo:
+		return "INFO"
+	case LogLevelWarn:
+		return "WARNING"
+	case LogLevelError:
+		return "ERROR"
+	default:
+		return level.String()
+	}
+}
+
+func levelPrefix(scope string, labels map[LogLevel]string, level LogLevel) string {
+	return scope + " " + levelLabel(labels, level) + ": "
+}
+
+// WithLevelLabels is a chainable configuration function which replaces the
+// level names in record prefixes, e.g. with ShortLevelLabels. Levels missing
+// from labels keep their default name. It must be applied after any
+// With*Logger calls.
+func (ll *DefaultLeveledLogger) WithLevelLabels(labels map[LogLevel]string) *DefaultLeveledLogger {
+	ll.labels = make(map[LogLevel]string, len(labels))
+	for level, label := range labels {
+		ll.labels[level] = label
+	}
+
+	for level, logger := range map[LogLevel]*log.Logger{
+		LogLevelTrace: ll.trace,
+		LogLevelDebug: ll.debug,
+		LogLevelInfo:  ll.info,
+		LogLevelWarn:  ll.warn,
+		LogLevelError: ll.err,
+	} {
+		logger.SetPrefix(ll.recordPrefix(ll.scope, level))
+	}
+	return ll
+}
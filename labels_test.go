@@ -0,0 +1,65 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package logging_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/pion/logging"
+)
+
+func logEveryLevel(logger logging.LeveledLogger) {
+	logger.Trace("t")
+	logger.Debug("d")
+	logger.Info("i")
+	logger.Warn("w")
+	logger.Error("e")
+}
+
+func TestLevelLabels(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		labels   map[logging.LogLevel]string
+		expected string
+	}{
+		{"default", nil, "ice TRACE: t\nice DEBUG: d\nice INFO: i\nice WARNING: w\nice ERROR: e\n"},
+		{"short", logging.ShortLevelLabels(), "ice TRC: t\nice DBG: d\nice INF: i\nice WRN: w\nice ERR: e\n"},
+		{
+			"custom",
+			map[logging.LogLevel]string{logging.LogLevelWarn: "warn", logging.LogLevelError: "fatal"},
+			"ice TRACE: t\nice DEBUG: d\nice INFO: i\nice warn: w\nice fatal: e\n",
+		},
+	} {
+		var outBuf bytes.Buffer
+		f := logging.New(
+			logging.WithWriter(&outBuf),
+			logging.WithLevel(logging.LogLevelTrace),
+			logging.WithTimestampLayout(""),
+			logging.WithLevelLabels(test.labels),
+		)
+		logger, ok := f.NewLogger("ice").(*logging.DefaultLeveledLogger)
+		if !ok {
+			t.Fatal("Invalid logger type")
+		}
+
+		logEveryLevel(logger.WithCaller(false, 0))
+		if outBuf.String() != test.expected {
+			t.Errorf("%s: expected %q, got %q", test.name, test.expected, outBuf.String())
+		}
+	}
+}
+
+func TestLevelLabelsWithScope(t *testing.T) {
+	var outBuf bytes.Buffer
+	logger := logging.
+		NewDefaultLeveledLoggerForScope("ice", logging.LogLevelInfo, &outBuf).
+		WithTimestamp("").
+		WithLevelLabels(logging.ShortLevelLabels())
+
+	logger.WithScope("agent").Warn("w")
+	if expected := "ice.agent WRN: w\n"; outBuf.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, outBuf.String())
+	}
+}
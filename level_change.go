@@ -0,0 +1,60 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package logging
+
+import "sort"
+
+// OnLevelChange registers a function called whenever the effective level of
+// a scope changes through SetScopeLevel, SetDefaultLogLevel, ReloadFromEnv or
+// the handler returned by ServeLevelsHTTP. It is called for every known
+// scope, as reported by Scopes, after the change is applied.
+func (f *DefaultLoggerFactory) OnLevelChange(observer func(scope string, oldLevel, newLevel LogLevel)) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.observers = append(f.observers, observer)
+}
+
+// changeLevels applies change, updates the issued loggers and then notifies
+// the observers of every scope whose level changed
+func (f *DefaultLoggerFactory) changeLevels(change func()) {
+	f.mu.Lock()
+	before, oldDefault := f.scopes(), f.DefaultLogLevel
+	change()
+	f.updateLevels()
+	after, newDefault := f.scopes(), f.DefaultLogLevel
+	observers := f.observers
+	f.mu.Unlock()
+
+	if len(observers) == 0 {
+		return
+	}
+
+	scopes := make([]string, 0, len(after))
+	for scope := range after {
+		scopes = append(scopes, scope)
+	}
+	for scope := range before {
+		if _, found := after[scope]; !found {
+			scopes = append(scopes, scope)
+		}
+	}
+	sort.Strings(scopes)
+
+	for _, scope := range scopes {
+		oldLevel, found := before[scope]
+		if !found {
+			oldLevel = oldDefault
+		}
+		newLevel, found := after[scope]
+		if !found {
+			newLevel = newDefault
+		}
+		if oldLevel == newLevel {
+			continue
+		}
+		for _, observer := range observers {
+			observer(scope, oldLevel, newLevel)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package logging
+
+import "io"
+
+// levelWriter is implemented by writers that handle records differently per
+// level, such as SyslogWriter and LevelFilterWriter
+type levelWriter interface {
+	LevelWriter(level LogLevel) io.Writer
+}
+
+// atLevelWriter is implemented by writers that handle records per level
+// without allocating a writer for every record
+type atLevelWriter interface {
+	writeAtLevel(level LogLevel, data []byte) (int, error)
+}
+
+// writeAtLevel writes a record at the given level to w, through its
+// LevelWriter if it has one
+func writeAtLevel(w io.Writer, level LogLevel, data []byte) (int, error) {
+	switch w := w.(type) {
+	case atLevelWriter:
+		return w.writeAtLevel(level, data)
+	case levelWriter:
+		return w.LevelWriter(level).Write(data)
+	default:
+		return w.Write(data)
+	}
+}
+
+// LevelFilterWriter drops records less severe than its level before they
+// reach the wrapped io.Writer. Loggers and factories using it as their
+// output write each record at its level, also through this package's
+// CountingWriter and BufferedWriter; other callers write through the
+// io.Writer returned by LevelWriter, which determines the level. Wrapping it
+// in any other writer hides the level and passes all records through.
+type LevelFilterWriter struct {
+	next  io.Writer
+	level LogLevel
+}
+
+// NewLevelFilterWriter returns a LevelFilterWriter passing records at level
+// or more severe to next
+func NewLevelFilterWriter(next io.Writer, level LogLevel) *LevelFilterWriter {
+	return &LevelFilterWriter{next: next, level: level}
+}
+
+// SetLevel sets the least severe level passed through
+func (w *LevelFilterWriter) SetLevel(newLevel LogLevel) {
+	w.level.Set(newLevel)
+}
+
+// Write passes data through unfiltered, for records of unknown level. This
+// also lets filters wrap each other.
+func (w *LevelFilterWriter) Write(data []byte) (int, error) {
+	return w.next.Write(data)
+}
+
+// LevelWriter returns an io.Writer for records at the given level. If the
+// wrapped writer handles levels itself, writes are forwarded at that level.
+func (w *LevelFilterWriter) LevelWriter(level LogLevel) io.Writer {
+	return &levelFilterWriter{filter: w, level: level}
+}
+
+type levelFilterWriter struct {
+	filter *LevelFilterWriter
+	level  LogLevel
+}
+
+func (w *levelFilterWriter) Write(data []byte) (int, error) {
+	return w.filter.writeAtLevel(w.level, data)
+}
+
+func (w *LevelFilterWriter) passes(level LogLevel) bool {
+	return level > LogLevelDisabled && level <= w.level.Get()
+}
+
+func (w *LevelFilterWriter) writeAtLevel(level LogLevel, data []byte) (int, error) {
+	if !w.passes(level) {
+		return len(data), nil
+	}
+	return writeAtLevel(w.next, level, data)
+}
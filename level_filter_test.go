@@ -0,0 +1,103 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package logging_test
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/pion/logging"
+)
+
+func TestLevelFilterWriter(t *testing.T) {
+	var outBuf bytes.Buffer
+	filter := logging.NewLevelFilterWriter(&outBuf, logging.LogLevelWarn)
+
+	for _, level := range []logging.LogLevel{
+		logging.LogLevelTrace, logging.LogLevelDebug, logging.LogLevelInfo,
+		logging.LogLevelWarn, logging.LogLevelError,
+	} {
+		if _, err := io.WriteString(filter.LevelWriter(level), level.String()+"\n"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if expected := "Warn\nError\n"; outBuf.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, outBuf.String())
+	}
+
+	outBuf.Reset()
+	info := filter.LevelWriter(logging.LogLevelInfo)
+	filter.SetLevel(logging.LogLevelInfo)
+	if _, err := io.WriteString(info, "passed\n"); err != nil {
+		t.Fatal(err)
+	}
+	if expected := "passed\n"; outBuf.String() != expected {
+		t.Errorf("Expected %q after SetLevel, got %q", expected, outBuf.String())
+	}
+}
+
+func TestLevelFilterWriterLogger(t *testing.T) {
+	var outBuf bytes.Buffer
+	filter := logging.NewLevelFilterWriter(logging.NewLevelFilterWriter(&outBuf, logging.LogLevelDebug), logging.LogLevelInfo)
+
+	logger := logging.NewDefaultLeveledLoggerForScope("testFilter", logging.LogLevelTrace, filter).
+		WithTimestamp("").
+		WithCaller(false, 0)
+	logger.Debug("dropped")
+	logger.Info("kept")
+	logger.WithScope("child").Warn("kept")
+	if expected := "testFilter INFO: kept\ntestFilter.child WARNING: kept\n"; outBuf.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, outBuf.String())
+	}
+
+	var otherBuf bytes.Buffer
+	logger.WithOutput(logging.NewLevelFilterWriter(&otherBuf, logging.LogLevelError))
+	logger.Warn("dropped")
+	logger.Error("kept")
+	if expected := "testFilter ERROR: kept\n"; otherBuf.String() != expected {
+		t.Errorf("Expected %q after WithOutput, got %q", expected, otherBuf.String())
+	}
+}
+
+func TestLevelFilterWriterFactory(t *testing.T) {
+	var outBuf bytes.Buffer
+	f := &logging.DefaultLoggerFactory{
+		Writer:           logging.NewLevelFilterWriter(&outBuf, logging.LogLevelWarn),
+		DefaultLogLevel:  logging.LogLevelTrace,
+		DisableTimestamp: true,
+	}
+
+	logger := f.NewLogger("ice")
+	logger.Info("dropped")
+	logger.Warn("kept")
+	if expected := "ice WARNING: kept\n"; outBuf.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, outBuf.String())
+	}
+}
+
+func TestLevelFilterWriterWrapped(t *testing.T) {
+	var outBuf bytes.Buffer
+	counting := logging.NewCountingWriter(logging.NewLevelFilterWriter(&outBuf, logging.LogLevelWarn))
+	buffered := logging.NewBufferedWriter(counting, 1024, time.Hour)
+
+	logger := logging.NewDefaultLeveledLoggerForScope("testFilter", logging.LogLevelTrace, buffered).
+		WithTimestamp("").
+		WithCaller(false, 0)
+	logger.Debug("dropped")
+	logger.Warn("kept")
+	logger.Info("dropped")
+	logger.Error("kept")
+	if err := buffered.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := "testFilter WARNING: kept\ntestFilter ERROR: kept\n"; outBuf.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, outBuf.String())
+	}
+	if counting.Lines() != 4 {
+		t.Errorf("Expected the counting writer to see 4 lines, got %d", counting.Lines())
+	}
+}
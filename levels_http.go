@@ -0,0 +1,63 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package logging
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+type levelsResponse struct {
+	Default string            `json:"default"`
+	Scopes  map[string]string `json:"scopes"`
+}
+
+// ServeLevelsHTTP returns an http.Handler to inspect and change log levels at
+// runtime. GET lists the default and per-scope levels as JSON. PUT and POST
+// take "level" and an optional "scope" form value; without a scope the
+// default level is changed. Loggers already created by the factory pick up
+// the new level.
+func (f *DefaultLoggerFactory) ServeLevelsHTTP() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+		case http.MethodPut, http.MethodPost:
+			level, err := ParseLevel(r.FormValue("level"))
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+
+			if scope := r.FormValue("scope"); scope != "" {
+				f.SetScopeLevel(scope, level)
+			} else {
+				f.SetDefaultLogLevel(level)
+			}
+		default:
+			w.Header().Set("Allow", strings.Join([]string{http.MethodGet, http.MethodPut, http.MethodPost}, ", "))
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(f.levelsResponse()); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+}
+
+func (f *DefaultLoggerFactory) levelsResponse() levelsResponse {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	resp := levelsResponse{
+		Default: f.DefaultLogLevel.String(),
+		Scopes:  make(map[string]string, len(f.ScopeLevels)),
+	}
+	for scope, level := range f.ScopeLevels {
+		resp.Scopes[scope] = level.String()
+	}
+	return resp
+}
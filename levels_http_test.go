@@ -0,0 +1,79 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package logging_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/pion/logging"
+)
+
+func serveLevels(t *testing.T, handler http.Handler, method, target string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(method, target, nil))
+	return rec
+}
+
+func TestServeLevelsHTTP(t *testing.T) {
+	var outBuf bytes.Buffer
+	f := logging.DefaultLoggerFactory{
+		Writer:          &outBuf,
+		DefaultLogLevel: logging.LogLevelWarn,
+		ScopeLevels: map[string]logging.LogLevel{
+			"dtls": logging.LogLevelInfo,
+		},
+	}
+	handler := f.ServeLevelsHTTP()
+
+	iceLogger := f.NewLogger("ice")
+	sctpLogger := f.NewLogger("sctp")
+	iceLogger.Debug("gated debug")
+	if outBuf.Len() > 0 {
+		t.Fatal("Debug was logged when it shouldn't have been")
+	}
+
+	rec := serveLevels(t, handler, http.MethodPut, "/?scope=ice&level=debug")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Unexpected status %d: %s", rec.Code, rec.Body.String())
+	}
+	iceLogger.Debug("visible debug")
+	if !strings.Contains(outBuf.String(), "visible debug") {
+		t.Errorf("Expected debug line after level update, got %q", outBuf.String())
+	}
+
+	rec = serveLevels(t, handler, http.MethodPost, "/?level=INFO")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Unexpected status %d: %s", rec.Code, rec.Body.String())
+	}
+	sctpLogger.Info("default info")
+	if !strings.Contains(outBuf.String(), "default info") {
+		t.Errorf("Expected info line after default level update, got %q", outBuf.String())
+	}
+
+	rec = serveLevels(t, handler, http.MethodGet, "/")
+	var levels struct {
+		Default string            `json:"default"`
+		Scopes  map[string]string `json:"scopes"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &levels); err != nil {
+		t.Fatal(err)
+	}
+	if levels.Default != "Info" || levels.Scopes["ice"] != "Debug" || levels.Scopes["dtls"] != "Info" {
+		t.Errorf("Unexpected levels %+v", levels)
+	}
+
+	if rec = serveLevels(t, handler, http.MethodPut, "/?scope=ice&level=verbose"); rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d for an invalid level, got %d", http.StatusBadRequest, rec.Code)
+	}
+	if rec = serveLevels(t, handler, http.MethodDelete, "/"); rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected status %d, got %d", http.StatusMethodNotAllowed, rec.Code)
+	}
+}
@@ -0,0 +1,55 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package logging
+
+import (
+	"log"
+	"strings"
+)
+
+// WithLogfmt is a chainable configuration function which emits records as
+// logfmt key=value pairs, such as `level=info scope=ice msg="gathering done"`,
+// followed by the global and per-call fields. A time field is added when a
+// layout is set with WithTimestamp. The flags of every level are cleared, so
+// call sites aren't reported. It must be applied after any With*Logger and
+// WithCaller calls.
+func (ll *DefaultLeveledLogger) WithLogfmt(enabled bool) *DefaultLeveledLogger {
+	ll.logfmt = enabled
+	for level, logger := range map[LogLevel]*log.Logger{
+		LogLevelTrace: ll.trace,
+		LogLevelDebug: ll.debug,
+		LogLevelInfo:  ll.info,
+		LogLevelWarn:  ll.warn,
+		LogLevelError: ll.err,
+	} {
+		if enabled {
+			logger.SetFlags(0)
+		}
+		logger.SetPrefix(ll.recordPrefix(ll.scope, level))
+	}
+	return ll
+}
+
+// recordPrefix returns the log.Logger prefix of records at level, which is
+// empty for logfmt records since they carry the scope and level as fields
+func (ll *DefaultLeveledLogger) recordPrefix(scope string, level LogLevel) string {
+	if ll.logfmt {
+		return ""
+	}
+	return levelPrefix(scope, ll.labels, level)
+}
+
+// logfmtRecord returns the leading fields of a logfmt record
+func (ll *DefaultLeveledLogger) logfmtRecord(level LogLevel, msg string) string {
+	var b strings.Builder
+	if ll.timestampLayout != "" {
+		appendField(&b, "time", ll.now().Format(ll.timestampLayout))
+	}
+	appendField(&b, "level", strings.ToLower(level.String()))
+	if ll.scope != "" {
+		appendField(&b, "scope", ll.scope)
+	}
+	appendField(&b, "msg", msg)
+	return b.String()
+}
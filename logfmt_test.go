@@ -0,0 +1,63 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package logging_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pion/logging"
+)
+
+func TestWithLogfmt(t *testing.T) {
+	var outBuf bytes.Buffer
+	now := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+	logger := logging.
+		NewDefaultLeveledLoggerForScope("ice", logging.LogLevelDebug, &outBuf).
+		WithClock(func() time.Time { return now }).
+		WithTimestamp(time.RFC3339).
+		WithGlobalFields("node", "edge-1").
+		WithLogfmt(true)
+
+	logger.Debug("checking pair")
+	logger.WarnKV("pair failed", "pair", "a:b")
+	logger.WithScope("agent").Errorf("%d pairs left", 0)
+
+	expected := `time=2023-05-01T12:00:00Z level=debug scope=ice msg="checking pair" node=edge-1` + "\n" +
+		`time=2023-05-01T12:00:00Z level=warn scope=ice msg="pair failed" node=edge-1 pair=a:b` + "\n" +
+		`time=2023-05-01T12:00:00Z level=error scope=ice.agent msg="0 pairs left" node=edge-1` + "\n"
+	if outBuf.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, outBuf.String())
+	}
+
+	outBuf.Reset()
+	logger.WithLogfmt(false).WithCaller(false, 0).Info("plain")
+	if expected := "ice INFO: 2023-05-01T12:00:00Z plain node=edge-1\n"; outBuf.String() != expected {
+		t.Errorf("Expected %q after disabling logfmt, got %q", expected, outBuf.String())
+	}
+}
+
+func TestFactoryLogfmt(t *testing.T) {
+	var outBuf bytes.Buffer
+	f := &logging.DefaultLoggerFactory{
+		Writer:          &outBuf,
+		DefaultLogLevel: logging.LogLevelInfo,
+		Logfmt:          true,
+	}
+
+	f.NewLogger("sctp").Info("association established")
+	out := outBuf.String()
+	timestamp, rest, found := strings.Cut(strings.TrimPrefix(out, "time="), " ")
+	if !found {
+		t.Fatalf("Expected a time field in %q", out)
+	}
+	if _, err := time.Parse(time.RFC3339, timestamp); err != nil {
+		t.Errorf("Expected an RFC3339 time field in %q: %v", out, err)
+	}
+	if expected := `level=info scope=sctp msg="association established"` + "\n"; rest != expected {
+		t.Errorf("Expected %q after the time field, got %q", expected, rest)
+	}
+}
@@ -5,19 +5,36 @@
 package logging
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"log"
 	"os"
+	"os/signal"
+	"reflect"
+	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
+var errHookPanicked = errors.New("log hook panicked")
+
+// processStart is the reference for the uptime reported by WithUptime
+var processStart = time.Now() //nolint:gochecknoglobals
+
 // Use this abstraction to ensure thread-safe access to the logger's io.Writer
-// (which could change at runtime)
+// (which could change at runtime). Writes are serialized as well, since the
+// per-level loggers share the same output.
 type loggerWriter struct {
-	sync.RWMutex
+	sync.Mutex
 	output io.Writer
+
+	// terminator replaces the newline log.Logger ends records with
+	terminator       string
+	customTerminator bool
 }
 
 func (lw *loggerWriter) SetOutput(output io.Writer) {
@@ -27,21 +44,113 @@ func (lw *loggerWriter) SetOutput(output io.Writer) {
 }
 
 func (lw *loggerWriter) Write(data []byte) (int, error) {
-	lw.RLock()
-	defer lw.RUnlock()
+	lw.Lock()
+	defer lw.Unlock()
 	return lw.output.Write(data)
 }
 
+func (lw *loggerWriter) writeAtLevel(level LogLevel, data []byte) (int, error) {
+	lw.Lock()
+	defer lw.Unlock()
+	if !lw.customTerminator || !bytes.HasSuffix(data, []byte{'\n'}) {
+		return writeAtLevel(lw.output, level, data)
+	}
+
+	record := make([]byte, 0, len(data)-1+len(lw.terminator))
+	record = append(record, data[:len(data)-1]...)
+	record = append(record, lw.terminator...)
+	if _, err := writeAtLevel(lw.output, level, record); err != nil {
+		return 0, err
+	}
+	return len(data), nil
+}
+
+// levelOutput is the output of the logger of one level, which lets the
+// logger's output handle records per level
+type levelOutput struct {
+	writer *loggerWriter
+	level  LogLevel
+}
+
+func (lo *levelOutput) Write(data []byte) (int, error) {
+	return lo.writer.writeAtLevel(lo.level, data)
+}
+
+// syncWriter serializes writes to an io.Writer shared by several loggers, so
+// that every record is written in one piece
+type syncWriter struct {
+	mu     sync.Mutex
+	output io.Writer
+	closed bool
+}
+
+func (sw *syncWriter) Write(data []byte) (int, error) {
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+	if sw.closed {
+		return len(data), nil
+	}
+	return sw.output.Write(data)
+}
+
+func (sw *syncWriter) writeAtLevel(level LogLevel, data []byte) (int, error) {
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+	if sw.closed {
+		return len(data), nil
+	}
+	return writeAtLevel(sw.output, level, data)
+}
+
+// loggerLevel is the level of a DefaultLeveledLogger. Loggers created by a
+// factory follow the level the factory keeps for their scope until SetLevel
+// is called on them.
+type loggerLevel struct {
+	own    LogLevel
+	pinned int32
+	scope  *LogLevel
+}
+
+func (l *loggerLevel) Get() LogLevel {
+	if l.scope == nil || atomic.LoadInt32(&l.pinned) == 1 {
+		return l.own.Get()
+	}
+	return l.scope.Get()
+}
+
+func (l *loggerLevel) Set(newLevel LogLevel) {
+	l.own.Set(newLevel)
+	atomic.StoreInt32(&l.pinned, 1)
+}
+
 // DefaultLeveledLogger encapsulates functionality for providing logging at
 // user-defined levels
 type DefaultLeveledLogger struct {
-	level  LogLevel
+	scope  string
+	level  *loggerLevel
 	writer *loggerWriter
 	trace  *log.Logger
 	debug  *log.Logger
 	info   *log.Logger
 	warn   *log.Logger
 	err    *log.Logger
+
+	callerSkip      int
+	wrapperSkip     int
+	timestampLayout string
+	uptimeStart     time.Time
+	errorHandler    func(error)
+	errorNotice     *sync.Once
+	sortFields      bool
+	strictFormat    bool
+	fields          string
+	clock           func() time.Time
+	labels          map[LogLevel]string
+	logfmt          bool
+
+	hooksMu sync.RWMutex
+	hooks   []func(LogLevel, string)
+	filters []func(LogLevel, string) bool
 }
 
 // WithTraceLogger is a chainable configuration function which sets the
@@ -86,26 +195,220 @@ func (ll *DefaultLeveledLogger) WithOutput(output io.Writer) *DefaultLeveledLogg
 	return ll
 }
 
+// WithTerminator is a chainable configuration function which ends every
+// record with terminator instead of a newline, e.g. "\r\n", or nothing for
+// outputs that frame records themselves. Records are still written with a
+// single Write. Like the output, the terminator is shared with sub-scopes.
+func (ll *DefaultLeveledLogger) WithTerminator(terminator string) *DefaultLeveledLogger {
+	ll.writer.Lock()
+	defer ll.writer.Unlock()
+	ll.writer.terminator = terminator
+	ll.writer.customTerminator = true
+	return ll
+}
+
+// WithCaller is a chainable configuration function which enables or disables
+// the file:line of the call site on every level. skip is the number of
+// additional stack frames to skip, for callers that wrap the logger in their
+// own helpers. It must be applied after any With*Logger calls.
+func (ll *DefaultLeveledLogger) WithCaller(enabled bool, skip int) *DefaultLeveledLogger {
+	for _, logger := range []*log.Logger{ll.trace, ll.debug, ll.info, ll.warn, ll.err} {
+		flags := logger.Flags() &^ (log.Lshortfile | log.Llongfile)
+		if enabled {
+			flags |= log.Lshortfile
+		}
+		logger.SetFlags(flags)
+	}
+	ll.callerSkip = skip
+	return ll
+}
+
+// callerSkipper is implemented by loggers which can account for the stack
+// frames of loggers wrapping them when reporting the call site
+type callerSkipper interface {
+	addCallerSkip(skip int)
+}
+
+// skipCallers makes logger skip the given number of additional stack frames
+// when reporting the call site, if it supports it
+func skipCallers(logger interface{}, skip int) {
+	if skipper, ok := logger.(callerSkipper); ok {
+		skipper.addCallerSkip(skip)
+	}
+}
+
+func (ll *DefaultLeveledLogger) addCallerSkip(skip int) {
+	ll.wrapperSkip += skip
+}
+
+// WithTimestamp is a chainable configuration function which replaces the
+// timestamp of every level with the current time formatted with layout, as
+// accepted by time.Format. An empty layout disables timestamps. It must be
+// applied after any With*Logger calls.
+func (ll *DefaultLeveledLogger) WithTimestamp(layout string) *DefaultLeveledLogger {
+	for _, logger := range []*log.Logger{ll.trace, ll.debug, ll.info, ll.warn, ll.err} {
+		logger.SetFlags(logger.Flags() &^ (log.Ldate | log.Ltime | log.Lmicroseconds | log.LUTC))
+	}
+	ll.timestampLayout = layout
+	return ll
+}
+
+// WithUptime is a chainable configuration function which appends the time
+// elapsed since start to every record as an uptime field
+func (ll *DefaultLeveledLogger) WithUptime(start time.Time) *DefaultLeveledLogger {
+	ll.uptimeStart = start
+	return ll
+}
+
+// WithClock is a chainable configuration function which sets the function
+// used to read the current time for timestamp layouts, uptimes and timers,
+// e.g. to make them deterministic in tests
+func (ll *DefaultLeveledLogger) WithClock(now func() time.Time) *DefaultLeveledLogger {
+	ll.clock = now
+	return ll
+}
+
+func (ll *DefaultLeveledLogger) now() time.Time {
+	if ll.clock != nil {
+		return ll.clock()
+	}
+	return time.Now()
+}
+
+// WithErrorHandler is a chainable configuration function which sets the
+// function called when a record can't be written. By default a notice is
+// printed to os.Stderr for the first error only, shared by the loggers of a
+// factory.
+func (ll *DefaultLeveledLogger) WithErrorHandler(handler func(error)) *DefaultLeveledLogger {
+	ll.errorHandler = handler
+	return ll
+}
+
 func (ll *DefaultLeveledLogger) logf(logger *log.Logger, level LogLevel, format string, args ...interface{}) {
 	if ll.level.Get() < level {
 		return
 	}
+	ll.output(logger, level, ll.sprintf(format, args), "", 0)
+}
+
+// logMsg emits the preformatted msg, standing in for logf
+func (ll *DefaultLeveledLogger) logMsg(logger *log.Logger, level LogLevel, msg string) {
+	if ll.level.Get() < level {
+		return
+	}
+	ll.output(logger, level, msg, "", 0)
+}
+
+// output writes msg followed by the global fields and the per-call fields.
+// skip is the number of stack frames between the caller and the function
+// which called logf, usually none.
+func (ll *DefaultLeveledLogger) output(logger *log.Logger, level LogLevel, msg, fields string, skip int) {
+	if !ll.runFilters(level, msg) {
+		return
+	}
+
+	callDepth := 4 + skip + ll.callerSkip + ll.wrapperSkip // this frame + logf + wrapper func + caller
+	var record string
+	switch {
+	case ll.logfmt:
+		record = ll.logfmtRecord(level, msg) + ll.fields + fields
+	case ll.timestampLayout != "":
+		record = ll.now().Format(ll.timestampLayout) + " " + msg + ll.fields + fields
+	default:
+		record = msg + ll.fields + fields
+	}
+	if !ll.uptimeStart.IsZero() {
+		record += " uptime=" + ll.now().Sub(ll.uptimeStart).String()
+	}
+	if err := logger.Output(callDepth, record); err != nil {
+		ll.handleError(err)
+	}
+	ll.runHooks(level, msg)
+}
+
+func (ll *DefaultLeveledLogger) handleError(err error) {
+	if ll.errorHandler != nil {
+		ll.errorHandler(err)
+		return
+	}
+	ll.errorNotice.Do(func() {
+		fmt.Fprintf(os.Stderr, "Unable to log: %s (further errors are not reported)\n", err)
+	})
+}
+
+// AddHook registers a function called with the level and message of every
+// record that passes the level filter. The message excludes any key/value
+// fields. Hooks run in registration order after
+// the record is written; a panicking hook is reported to the error handler.
+func (ll *DefaultLeveledLogger) AddHook(hook func(level LogLevel, msg string)) {
+	ll.hooksMu.Lock()
+	defer ll.hooksMu.Unlock()
+	ll.hooks = append(ll.hooks, hook)
+}
+
+func (ll *DefaultLeveledLogger) runHooks(level LogLevel, msg string) {
+	ll.hooksMu.RLock()
+	hooks := ll.hooks
+	ll.hooksMu.RUnlock()
+
+	for _, hook := range hooks {
+		ll.runHook(hook, level, msg)
+	}
+}
 
-	callDepth := 3 // this frame + wrapper func + caller
-	msg := fmt.Sprintf(format, args...)
-	if err := logger.Output(callDepth, msg); err != nil {
-		fmt.Fprintf(os.Stderr, "Unable to log: %s", err)
+func (ll *DefaultLeveledLogger) runHook(hook func(LogLevel, string), level LogLevel, msg string) {
+	defer func() {
+		if r := recover(); r != nil {
+			ll.handleError(fmt.Errorf("%w: %v", errHookPanicked, r))
+		}
+	}()
+	hook(level, msg)
+}
+
+// AddFilter registers a predicate called with the level and message of every
+// record that passes the level filter. The message excludes any key/value
+// fields. Records are dropped unless all filters
+// return true; dropped records don't reach the writer or the hooks.
+func (ll *DefaultLeveledLogger) AddFilter(filter func(level LogLevel, msg string) bool) {
+	ll.hooksMu.Lock()
+	defer ll.hooksMu.Unlock()
+	ll.filters = append(ll.filters, filter)
+}
+
+func (ll *DefaultLeveledLogger) runFilters(level LogLevel, msg string) bool {
+	ll.hooksMu.RLock()
+	filters := ll.filters
+	ll.hooksMu.RUnlock()
+
+	for _, filter := range filters {
+		if !filter(level, msg) {
+			return false
+		}
 	}
+	return true
 }
 
-// SetLevel sets the logger's logging level
+// SetLevel sets the logger's logging level. Level changes made through the
+// factory that created the logger no longer apply to it afterwards.
 func (ll *DefaultLeveledLogger) SetLevel(newLevel LogLevel) {
 	ll.level.Set(newLevel)
 }
 
+// Enabled reports whether records at the given level are emitted. It is the
+// recommended guard around expensive preparation of log messages.
+func (ll *DefaultLeveledLogger) Enabled(level LogLevel) bool {
+	return level > LogLevelDisabled && ll.level.Get() >= level
+}
+
+// IsNoop reports whether the logger is disabled, so that callers can skip
+// preparing anything that is only needed for logging
+func (ll *DefaultLeveledLogger) IsNoop() bool {
+	return ll.level.Get() == LogLevelDisabled
+}
+
 // Trace emits the preformatted message if the logger is at or below LogLevelTrace
 func (ll *DefaultLeveledLogger) Trace(msg string) {
-	ll.logf(ll.trace, LogLevelTrace, msg) // nolint: govet
+	ll.logMsg(ll.trace, LogLevelTrace, msg)
 }
 
 // Tracef formats and emits a message if the logger is at or below LogLevelTrace
@@ -115,7 +418,7 @@ func (ll *DefaultLeveledLogger) Tracef(format string, args ...interface{}) {
 
 // Debug emits the preformatted message if the logger is at or below LogLevelDebug
 func (ll *DefaultLeveledLogger) Debug(msg string) {
-	ll.logf(ll.debug, LogLevelDebug, msg) // nolint: govet
+	ll.logMsg(ll.debug, LogLevelDebug, msg)
 }
 
 // Debugf formats and emits a message if the logger is at or below LogLevelDebug
@@ -125,7 +428,7 @@ func (ll *DefaultLeveledLogger) Debugf(format string, args ...interface{}) {
 
 // Info emits the preformatted message if the logger is at or below LogLevelInfo
 func (ll *DefaultLeveledLogger) Info(msg string) {
-	ll.logf(ll.info, LogLevelInfo, msg) // nolint: govet
+	ll.logMsg(ll.info, LogLevelInfo, msg)
 }
 
 // Infof formats and emits a message if the logger is at or below LogLevelInfo
@@ -135,7 +438,7 @@ func (ll *DefaultLeveledLogger) Infof(format string, args ...interface{}) {
 
 // Warn emits the preformatted message if the logger is at or below LogLevelWarn
 func (ll *DefaultLeveledLogger) Warn(msg string) {
-	ll.logf(ll.warn, LogLevelWarn, msg) // nolint: govet
+	ll.logMsg(ll.warn, LogLevelWarn, msg)
 }
 
 // Warnf formats and emits a message if the logger is at or below LogLevelWarn
@@ -145,7 +448,7 @@ func (ll *DefaultLeveledLogger) Warnf(format string, args ...interface{}) {
 
 // Error emits the preformatted message if the logger is at or below LogLevelError
 func (ll *DefaultLeveledLogger) Error(msg string) {
-	ll.logf(ll.err, LogLevelError, msg) // nolint: govet
+	ll.logMsg(ll.err, LogLevelError, msg)
 }
 
 // Errorf formats and emits a message if the logger is at or below LogLevelError
@@ -153,36 +456,248 @@ func (ll *DefaultLeveledLogger) Errorf(format string, args ...interface{}) {
 	ll.logf(ll.err, LogLevelError, format, args...)
 }
 
+// Log emits the preformatted message if the logger is at or below the given level
+func (ll *DefaultLeveledLogger) Log(level LogLevel, msg string) {
+	if logger := ll.loggerForLevel(level); logger != nil {
+		ll.logMsg(logger, level, msg)
+	}
+}
+
+// Logf formats and emits a message if the logger is at or below the given level
+func (ll *DefaultLeveledLogger) Logf(level LogLevel, format string, args ...interface{}) {
+	if logger := ll.loggerForLevel(level); logger != nil {
+		ll.logf(logger, level, format, args...)
+	}
+}
+
+// LogStateTable emits the allowed transitions of a state machine at the given
+// level, with states sorted so that the output is deterministic
+func (ll *DefaultLeveledLogger) LogStateTable(level LogLevel, table map[string][]string) {
+	logger := ll.loggerForLevel(level)
+	if logger == nil || !ll.Enabled(level) {
+		return
+	}
+
+	states := make([]string, 0, len(table))
+	for state := range table {
+		states = append(states, state)
+	}
+	sort.Strings(states)
+
+	var msg strings.Builder
+	msg.WriteString("state table:")
+	for i, state := range states {
+		if i > 0 {
+			msg.WriteByte(';')
+		}
+		fmt.Fprintf(&msg, " %s -> [%s]", state, strings.Join(table[state], ", "))
+	}
+	ll.logf(logger, level, "%s", msg.String())
+}
+
+// Writer returns an io.Writer that emits every line written to it as a
+// record at the given level. Partial lines are buffered until their newline
+// is written.
+func (ll *DefaultLeveledLogger) Writer(level LogLevel) io.Writer {
+	return &lineWriter{logger: ll, level: level}
+}
+
+// StdLogger returns a standard library *log.Logger whose output is emitted
+// at the given level. It has no prefix or flags of its own, so records are
+// not timestamped twice.
+func (ll *DefaultLeveledLogger) StdLogger(level LogLevel) *log.Logger {
+	// skip the log.Logger print method and its output function
+	return log.New(&lineWriter{logger: ll, level: level, skip: 2}, "", 0)
+}
+
+type lineWriter struct {
+	logger *DefaultLeveledLogger
+	level  LogLevel
+	skip   int
+
+	mu  sync.Mutex
+	buf []byte
+}
+
+func (lw *lineWriter) Write(data []byte) (int, error) {
+	lw.mu.Lock()
+	defer lw.mu.Unlock()
+
+	lw.buf = append(lw.buf, data...)
+	for {
+		i := bytes.IndexByte(lw.buf, '\n')
+		if i < 0 {
+			break
+		}
+		lw.logger.logLine(lw.level, string(bytes.TrimSuffix(lw.buf[:i], []byte{'\r'})), lw.skip)
+		lw.buf = lw.buf[i+1:]
+	}
+	if len(lw.buf) == 0 {
+		lw.buf = nil
+	}
+	return len(data), nil
+}
+
+// logLine emits msg at the given level on behalf of lineWriter.Write, which
+// is skip frames below the caller
+func (ll *DefaultLeveledLogger) logLine(level LogLevel, msg string, skip int) {
+	if logger := ll.loggerForLevel(level); logger != nil && ll.Enabled(level) {
+		// this frame and lineWriter.Write stand in for logf and its wrapper
+		ll.output(logger, level, msg, "", skip)
+	}
+}
+
+func (ll *DefaultLeveledLogger) loggerForLevel(level LogLevel) *log.Logger {
+	switch level {
+	case LogLevelTrace:
+		return ll.trace
+	case LogLevelDebug:
+		return ll.debug
+	case LogLevelInfo:
+		return ll.info
+	case LogLevelWarn:
+		return ll.warn
+	case LogLevelError:
+		return ll.err
+	default:
+		return nil
+	}
+}
+
 // NewDefaultLeveledLoggerForScope returns a configured LeveledLogger
 func NewDefaultLeveledLoggerForScope(scope string, level LogLevel, writer io.Writer) *DefaultLeveledLogger {
+	return newDefaultLeveledLogger(scope, &loggerLevel{own: level}, writer)
+}
+
+func newDefaultLeveledLogger(scope string, level *loggerLevel, writer io.Writer) *DefaultLeveledLogger {
 	if writer == nil {
 		writer = os.Stderr
 	}
 	logger := &DefaultLeveledLogger{
-		writer: &loggerWriter{output: writer},
-		level:  level,
+		scope:       scope,
+		writer:      &loggerWriter{output: writer},
+		level:       level,
+		errorNotice: &sync.Once{},
+	}
+	newLogger := func(logLevel LogLevel, flags int) *log.Logger {
+		return log.New(&levelOutput{writer: logger.writer, level: logLevel}, levelPrefix(scope, nil, logLevel), flags)
 	}
 	return logger.
-		WithTraceLogger(log.New(logger.writer, fmt.Sprintf("%s TRACE: ", scope), log.Lmicroseconds|log.Lshortfile)).
-		WithDebugLogger(log.New(logger.writer, fmt.Sprintf("%s DEBUG: ", scope), log.Lmicroseconds|log.Lshortfile)).
-		WithInfoLogger(log.New(logger.writer, fmt.Sprintf("%s INFO: ", scope), log.LstdFlags)).
-		WithWarnLogger(log.New(logger.writer, fmt.Sprintf("%s WARNING: ", scope), log.LstdFlags)).
-		WithErrorLogger(log.New(logger.writer, fmt.Sprintf("%s ERROR: ", scope), log.LstdFlags))
+		WithTraceLogger(newLogger(LogLevelTrace, log.Lmicroseconds|log.Lshortfile)).
+		WithDebugLogger(newLogger(LogLevelDebug, log.Lmicroseconds|log.Lshortfile)).
+		WithInfoLogger(newLogger(LogLevelInfo, log.LstdFlags)).
+		WithWarnLogger(newLogger(LogLevelWarn, log.LstdFlags)).
+		WithErrorLogger(newLogger(LogLevelError, log.LstdFlags))
 }
 
-// DefaultLoggerFactory define levels by scopes and creates new DefaultLeveledLogger
+// WithScope returns a logger for a sub-scope, named by joining the scope of
+// ll and suffix with a dot. The returned logger shares the level and the
+// per-level outputs of ll and copies its flags, settings and hooks.
+func (ll *DefaultLeveledLogger) WithScope(suffix string) *DefaultLeveledLogger {
+	scope := suffix
+	if ll.scope != "" {
+		scope = ll.scope + "." + suffix
+	}
+
+	child := &DefaultLeveledLogger{
+		scope:           scope,
+		level:           ll.level,
+		writer:          ll.writer,
+		callerSkip:      ll.callerSkip,
+		wrapperSkip:     ll.wrapperSkip,
+		timestampLayout: ll.timestampLayout,
+		uptimeStart:     ll.uptimeStart,
+		errorHandler:    ll.errorHandler,
+		errorNotice:     ll.errorNotice,
+		sortFields:      ll.sortFields,
+		strictFormat:    ll.strictFormat,
+		fields:          ll.fields,
+		clock:           ll.clock,
+		labels:          ll.labels,
+		logfmt:          ll.logfmt,
+	}
+
+	ll.hooksMu.RLock()
+	child.hooks = append(child.hooks, ll.hooks...)
+	child.filters = append(child.filters, ll.filters...)
+	ll.hooksMu.RUnlock()
+
+	return child.
+		WithTraceLogger(log.New(ll.trace.Writer(), child.recordPrefix(scope, LogLevelTrace), ll.trace.Flags())).
+		WithDebugLogger(log.New(ll.debug.Writer(), child.recordPrefix(scope, LogLevelDebug), ll.debug.Flags())).
+		WithInfoLogger(log.New(ll.info.Writer(), child.recordPrefix(scope, LogLevelInfo), ll.info.Flags())).
+		WithWarnLogger(log.New(ll.warn.Writer(), child.recordPrefix(scope, LogLevelWarn), ll.warn.Flags())).
+		WithErrorLogger(log.New(ll.err.Writer(), child.recordPrefix(scope, LogLevelError), ll.err.Flags()))
+}
+
+// DefaultLoggerFactory define levels by scopes and creates new DefaultLeveledLogger.
+// Scopes are matched case insensitively.
 type DefaultLoggerFactory struct {
 	Writer          io.Writer
 	DefaultLogLevel LogLevel
 	ScopeLevels     map[string]LogLevel
+	// DisableTimestamp removes timestamps from every record
+	DisableTimestamp bool
+	// TimestampLayout replaces the default timestamps with the given
+	// time.Format layout, unless DisableTimestamp is set
+	TimestampLayout string
+	// ErrorHandler is called when a record can't be written, see
+	// DefaultLeveledLogger.WithErrorHandler
+	ErrorHandler func(error)
+	// GlobalFields are key/value pairs emitted on every record, see
+	// DefaultLeveledLogger.WithGlobalFields
+	GlobalFields []interface{}
+	// LevelLabels replaces the level names in record prefixes, see
+	// DefaultLeveledLogger.WithLevelLabels
+	LevelLabels map[LogLevel]string
+	// Logfmt emits records as logfmt key=value pairs, timestamped with
+	// time.RFC3339 unless TimestampLayout or DisableTimestamp is set, see
+	// DefaultLeveledLogger.WithLogfmt
+	Logfmt bool
+
+	uptime bool
+
+	// levels parsed from the environment, used to report LevelSource
+	env envLevels
+
+	// levels followed by the loggers issued for each scope, so that level
+	// changes made through the factory reach loggers already handed out
+	mu          sync.Mutex
+	levels      map[string]*LogLevel
+	writer      *syncWriter
+	errorNotice *sync.Once
+	stops       []func()
+	closed      bool
+
+	observers []func(scope string, oldLevel, newLevel LogLevel)
+
+	closeOnce sync.Once
+	closeErr  error
 }
 
-// NewDefaultLoggerFactory creates a new DefaultLoggerFactory
-func NewDefaultLoggerFactory() *DefaultLoggerFactory {
-	factory := DefaultLoggerFactory{}
-	factory.DefaultLogLevel = LogLevelError
-	factory.ScopeLevels = make(map[string]LogLevel)
-	factory.Writer = os.Stderr
+// LevelSource describes where the effective level of a scope was configured
+type LevelSource string
+
+const (
+	// LevelSourceEnv is reported for levels set through PION_LOG_* variables
+	LevelSourceEnv LevelSource = "env"
+	// LevelSourceScope is reported for levels set in ScopeLevels by user code
+	LevelSourceScope LevelSource = "scope"
+	// LevelSourceDefault is reported for scopes using DefaultLogLevel
+	LevelSourceDefault LevelSource = "default"
+)
+
+// envLevels holds the levels configured through PION_LOG_* variables
+type envLevels struct {
+	defaultLevel LogLevel
+	hasDefault   bool
+	scopeLevels  map[string]LogLevel
+}
+
+// parseEnvLevels reads the PION_LOG_* variables. When several variables
+// apply to the default level or to the same scope, the most verbose wins.
+func parseEnvLevels() envLevels {
+	levels := envLevels{scopeLevels: make(map[string]LogLevel)}
 
 	logLevels := map[string]LogLevel{
 		"DISABLE": LogLevelDisabled,
@@ -205,28 +720,298 @@ func NewDefaultLoggerFactory() *DefaultLoggerFactory {
 		}
 
 		if strings.ToLower(env) == "all" {
-			factory.DefaultLogLevel = level
+			if !levels.hasDefault || levels.defaultLevel < level {
+				levels.defaultLevel = level
+				levels.hasDefault = true
+			}
 			continue
 		}
 
 		scopes := strings.Split(strings.ToLower(env), ",")
 		for _, scope := range scopes {
-			factory.ScopeLevels[scope] = level
+			if scopeLevel, found := levels.scopeLevels[scope]; !found || scopeLevel < level {
+				levels.scopeLevels[scope] = level
+			}
 		}
 	}
 
+	return levels
+}
+
+// level returns the level the variables set for scope, if any
+func (levels envLevels) level(scope string) (LogLevel, bool) {
+	if level, found := levels.scopeLevels[strings.ToLower(scope)]; found {
+		return level, true
+	}
+	return levels.defaultLevel, levels.hasDefault
+}
+
+// WithLevelFromEnv is a chainable configuration function which sets the
+// logger's level from the PION_LOG_* variables for the given scope, the way
+// DefaultLoggerFactory does. The level is left unchanged if no variable
+// applies to scope.
+func (ll *DefaultLeveledLogger) WithLevelFromEnv(scope string) *DefaultLeveledLogger {
+	if level, found := parseEnvLevels().level(scope); found {
+		ll.SetLevel(level)
+	}
+	return ll
+}
+
+// NewDefaultLoggerFactory creates a new DefaultLoggerFactory
+func NewDefaultLoggerFactory() *DefaultLoggerFactory {
+	factory := DefaultLoggerFactory{}
+	factory.DefaultLogLevel = LogLevelError
+	factory.ScopeLevels = make(map[string]LogLevel)
+	factory.Writer = os.Stderr
+	factory.applyEnvLevels(parseEnvLevels())
+
 	return &factory
 }
 
-// NewLogger returns a configured LeveledLogger for the given , argsscope
+// ReloadFromEnv parses the PION_LOG_* variables again and applies them to
+// the factory and to the loggers it already created. Levels that came from
+// the previous environment are reset; levels set in code are kept.
+func (f *DefaultLoggerFactory) ReloadFromEnv() {
+	levels := parseEnvLevels()
+
+	f.changeLevels(func() {
+		for scope, level := range f.env.scopeLevels {
+			if scopeLevel, found := f.ScopeLevels[scope]; found && scopeLevel == level {
+				delete(f.ScopeLevels, scope)
+			}
+		}
+		if f.env.hasDefault && f.DefaultLogLevel == f.env.defaultLevel {
+			f.DefaultLogLevel = LogLevelError
+		}
+
+		f.applyEnvLevels(levels)
+	})
+}
+
+// ReloadOnSignal calls ReloadFromEnv whenever one of the given signals,
+// typically syscall.SIGHUP, is received until stop is called. Without any
+// signal nothing is watched and stop does nothing.
+func (f *DefaultLoggerFactory) ReloadOnSignal(sig ...os.Signal) (stop func()) {
+	if len(sig) == 0 {
+		return func() {}
+	}
+
+	signals := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	signal.Notify(signals, sig...)
+
+	go func() {
+		for {
+			select {
+			case <-signals:
+				f.ReloadFromEnv()
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	stop = func() {
+		once.Do(func() {
+			signal.Stop(signals)
+			close(done)
+		})
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.stops = append(f.stops, stop)
+	return stop
+}
+
+func (f *DefaultLoggerFactory) applyEnvLevels(levels envLevels) {
+	if levels.hasDefault {
+		f.DefaultLogLevel = levels.defaultLevel
+	}
+	if f.ScopeLevels == nil {
+		f.ScopeLevels = make(map[string]LogLevel)
+	}
+	for scope, level := range levels.scopeLevels {
+		setScopeLevel(f.ScopeLevels, scope, level)
+	}
+	f.env = levels
+}
+
+// NewLogger returns a configured LeveledLogger for the given scope
 func (f *DefaultLoggerFactory) NewLogger(scope string) LeveledLogger {
-	logLevel := f.DefaultLogLevel
-	if f.ScopeLevels != nil {
-		scopeLevel, found := f.ScopeLevels[scope]
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.levels == nil {
+		f.levels = make(map[string]*LogLevel)
+	}
+	key := strings.ToLower(scope)
+	level, found := f.levels[key]
+	if !found {
+		level = new(LogLevel)
+		f.levels[key] = level
+	}
+	level.Set(f.effectiveLevel(scope))
+
+	logger := newDefaultLeveledLogger(scope, &loggerLevel{scope: level}, f.sharedWriter())
+	switch {
+	case f.DisableTimestamp:
+		logger.WithTimestamp("")
+	case f.TimestampLayout != "":
+		logger.WithTimestamp(f.TimestampLayout)
+	case f.Logfmt:
+		logger.WithTimestamp(time.RFC3339)
+	}
+	if f.uptime {
+		logger.WithUptime(processStart)
+	}
+	if f.ErrorHandler != nil {
+		logger.WithErrorHandler(f.ErrorHandler)
+	}
+	if f.errorNotice == nil {
+		f.errorNotice = &sync.Once{}
+	}
+	logger.errorNotice = f.errorNotice
+	if len(f.GlobalFields) > 0 {
+		logger.WithGlobalFields(f.GlobalFields...)
+	}
+	if f.LevelLabels != nil {
+		logger.WithLevelLabels(f.LevelLabels)
+	}
+	if f.Logfmt {
+		logger.WithLogfmt(true)
+	}
+	return logger
+}
+
+// WithUptime is a chainable configuration function which makes every logger
+// created afterwards append the time since the process started to its records
+func (f *DefaultLoggerFactory) WithUptime() *DefaultLoggerFactory {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.uptime = true
+	return f
+}
+
+// sharedWriter returns Writer wrapped so that writes from all loggers of the
+// factory are serialized. The wrapper is rebuilt when Writer is replaced,
+// which can only be detected for comparable writers.
+func (f *DefaultLoggerFactory) sharedWriter() *syncWriter {
+	if f.closed {
+		return f.writer
+	}
+
+	output := f.Writer
+	if output == nil {
+		output = os.Stderr
+	}
+	if f.writer == nil || (reflect.ValueOf(output).Comparable() && f.writer.output != output) {
+		f.writer = &syncWriter{output: output}
+	}
+	return f.writer
+}
+
+// SetScopeLevel sets the level of the given scope, including loggers that
+// were already created for it
+func (f *DefaultLoggerFactory) SetScopeLevel(scope string, level LogLevel) {
+	f.changeLevels(func() {
+		if f.ScopeLevels == nil {
+			f.ScopeLevels = make(map[string]LogLevel)
+		}
+		setScopeLevel(f.ScopeLevels, scope, level)
+	})
+}
+
+// SetDefaultLogLevel sets the level of every scope without an explicit
+// level, including loggers that were already created
+func (f *DefaultLoggerFactory) SetDefaultLogLevel(level LogLevel) {
+	f.changeLevels(func() {
+		f.DefaultLogLevel = level
+	})
+}
+
+func (f *DefaultLoggerFactory) effectiveLevel(scope string) LogLevel {
+	if scopeLevel, found := lookupScopeLevel(f.ScopeLevels, scope); found {
+		return scopeLevel
+	}
+	return f.DefaultLogLevel
+}
+
+// setScopeLevel stores level under the lowercased scope, replacing the
+// entries of scope spelled with a different case
+func setScopeLevel(levels map[string]LogLevel, scope string, level LogLevel) {
+	for name := range levels {
+		if strings.EqualFold(name, scope) {
+			delete(levels, name)
+		}
+	}
+	levels[strings.ToLower(scope)] = level
+}
+
+// lookupScopeLevel matches scope against the keys of levels case
+// insensitively, preferring an exact match
+func lookupScopeLevel(levels map[string]LogLevel, scope string) (LogLevel, bool) {
+	if level, found := levels[scope]; found {
+		return level, true
+	}
+	for name, level := range levels {
+		if strings.EqualFold(name, scope) {
+			return level, true
+		}
+	}
+	return LogLevelDisabled, false
+}
 
-		if found {
-			logLevel = scopeLevel
+func (f *DefaultLoggerFactory) updateLevels() {
+	for scope, level := range f.levels {
+		level.Set(f.effectiveLevel(scope))
+	}
+}
+
+// EffectiveLevel returns the level loggers for the given scope are created with
+func (f *DefaultLoggerFactory) EffectiveLevel(scope string) LogLevel {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.effectiveLevel(scope)
+}
+
+// Scopes returns the effective level of every known scope, that is the
+// scopes in ScopeLevels and those loggers were created for
+func (f *DefaultLoggerFactory) Scopes() map[string]LogLevel {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.scopes()
+}
+
+func (f *DefaultLoggerFactory) scopes() map[string]LogLevel {
+	scopes := make(map[string]LogLevel, len(f.ScopeLevels)+len(f.levels))
+	for scope := range f.levels {
+		if _, configured := lookupScopeLevel(f.ScopeLevels, scope); !configured {
+			scopes[scope] = f.DefaultLogLevel
 		}
 	}
-	return NewDefaultLeveledLoggerForScope(scope, logLevel, f.Writer)
+	for scope, level := range f.ScopeLevels {
+		scopes[scope] = level
+	}
+	return scopes
+}
+
+// LevelSource reports whether the level of the given scope comes from the
+// environment, from ScopeLevels or from DefaultLogLevel
+func (f *DefaultLoggerFactory) LevelSource(scope string) LevelSource {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if scopeLevel, found := lookupScopeLevel(f.ScopeLevels, scope); found {
+		if envLevel, fromEnv := lookupScopeLevel(f.env.scopeLevels, scope); fromEnv && envLevel == scopeLevel {
+			return LevelSourceEnv
+		}
+		return LevelSourceScope
+	}
+
+	if f.env.hasDefault && f.env.defaultLevel == f.DefaultLogLevel {
+		return LevelSourceEnv
+	}
+	return LevelSourceDefault
 }
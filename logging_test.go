@@ -5,9 +5,18 @@ package logging_test
 
 import (
 	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
 	"os"
+	"os/signal"
+	"runtime"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/pion/logging"
 )
@@ -115,3 +124,990 @@ func TestSetLevel(t *testing.T) {
 	logger.SetLevel(logging.LogLevelDebug)
 	testDebugLevel(t, logger)
 }
+
+func logThroughHelper(logger *logging.DefaultLeveledLogger, msg string) {
+	logger.Warn(msg)
+}
+
+func TestWithCaller(t *testing.T) {
+	var outBuf bytes.Buffer
+	logger := logging.
+		NewDefaultLeveledLoggerForScope("testWithCaller", logging.LogLevelDebug, &outBuf).
+		WithCaller(true, 0)
+
+	logger.Info("info message")
+	if !strings.Contains(outBuf.String(), "logging_test.go:") {
+		t.Errorf("Expected caller in %q, but didn't find it", outBuf.String())
+	}
+
+	outBuf.Reset()
+	logger.Debugf("debug %s", "message")
+	if !strings.Contains(outBuf.String(), "logging_test.go:") {
+		t.Errorf("Expected caller in %q, but didn't find it", outBuf.String())
+	}
+
+	outBuf.Reset()
+	logger.WithCaller(true, 1)
+	logThroughHelper(logger, "helper message")
+	_, _, line, _ := runtime.Caller(0)
+	if expected := fmt.Sprintf("logging_test.go:%d:", line-1); !strings.Contains(outBuf.String(), expected) {
+		t.Errorf("Expected to find %q in %q, but didn't", expected, outBuf.String())
+	}
+
+	outBuf.Reset()
+	logger.WithCaller(false, 0)
+	logger.Debug("debug message")
+	if strings.Contains(outBuf.String(), ".go:") {
+		t.Errorf("Expected no caller in %q", outBuf.String())
+	}
+}
+
+func TestLevelSource(t *testing.T) {
+	t.Setenv("PION_LOG_DEBUG", "ice")
+	t.Setenv("PION_LOG_WARN", "all")
+
+	f := logging.NewDefaultLoggerFactory()
+	f.ScopeLevels["dtls"] = logging.LogLevelInfo
+
+	for scope, expected := range map[string]logging.LevelSource{
+		"ice":  logging.LevelSourceEnv,
+		"dtls": logging.LevelSourceScope,
+		"sctp": logging.LevelSourceEnv,
+	} {
+		if source := f.LevelSource(scope); source != expected {
+			t.Errorf("Expected scope %q to have level source %q, got %q", scope, expected, source)
+		}
+	}
+
+	f.ScopeLevels["ice"] = logging.LogLevelTrace
+	if source := f.LevelSource("ice"); source != logging.LevelSourceScope {
+		t.Errorf("Expected overridden scope to have level source %q, got %q", logging.LevelSourceScope, source)
+	}
+
+	f.DefaultLogLevel = logging.LogLevelError
+	if source := f.LevelSource("sctp"); source != logging.LevelSourceDefault {
+		t.Errorf("Expected level source %q, got %q", logging.LevelSourceDefault, source)
+	}
+}
+
+func TestLogAtLevel(t *testing.T) {
+	var outBuf bytes.Buffer
+	logger := logging.
+		NewDefaultLeveledLoggerForScope("testLog", logging.LogLevelInfo, &outBuf)
+
+	for _, test := range []struct {
+		level   logging.LogLevel
+		prefix  string
+		emitted bool
+	}{
+		{logging.LogLevelTrace, "TRACE", false},
+		{logging.LogLevelDebug, "DEBUG", false},
+		{logging.LogLevelInfo, "INFO", true},
+		{logging.LogLevelWarn, "WARNING", true},
+		{logging.LogLevelError, "ERROR", true},
+		{logging.LogLevelDisabled, "", false},
+	} {
+		outBuf.Reset()
+		logger.Log(test.level, "dynamic message")
+		logger.Logf(test.level, "dynamic %s", "format")
+
+		out := outBuf.String()
+		if !test.emitted {
+			if out != "" {
+				t.Errorf("Level %s was logged when it shouldn't have been: %q", test.level, out)
+			}
+			continue
+		}
+		if !strings.Contains(out, "testLog "+test.prefix+": ") ||
+			!strings.Contains(out, "dynamic message") || !strings.Contains(out, "dynamic format") {
+			t.Errorf("Expected level %s record in %q", test.level, out)
+		}
+	}
+}
+
+func TestAllocations(t *testing.T) {
+	if raceEnabled {
+		t.Skip("allocation counts are not meaningful with the race detector")
+	}
+
+	logger := logging.
+		NewDefaultLeveledLoggerForScope("testAllocations", logging.LogLevelInfo, io.Discard)
+
+	for _, test := range []struct {
+		name   string
+		budget float64
+		log    func()
+	}{
+		{"filtered Debug", 0, func() { logger.Debug("filtered") }},
+		{"filtered Debugf", 0, func() { logger.Debugf("filtered %s", "arg") }},
+		{"Info", 1, func() { logger.Info("emitted") }},
+		{"Infof", 1, func() { logger.Infof("emitted %d %s", 1, "arg") }},
+	} {
+		if allocs := testing.AllocsPerRun(100, test.log); allocs > test.budget {
+			t.Errorf("%s allocated %v times per run, budget is %v", test.name, allocs, test.budget)
+		}
+	}
+}
+
+func TestReloadFromEnv(t *testing.T) {
+	t.Setenv("PION_LOG_DEBUG", "ice")
+
+	var outBuf bytes.Buffer
+	f := logging.NewDefaultLoggerFactory()
+	f.Writer = &outBuf
+	f.ScopeLevels["dtls"] = logging.LogLevelTrace
+
+	iceLogger := f.NewLogger("ice")
+	sctpLogger := f.NewLogger("sctp")
+	dtlsLogger := f.NewLogger("dtls")
+
+	t.Setenv("PION_LOG_DEBUG", "")
+	t.Setenv("PION_LOG_INFO", "all")
+	f.ReloadFromEnv()
+
+	iceLogger.Debug("ice debug")
+	sctpLogger.Info("sctp info")
+	dtlsLogger.Trace("dtls trace")
+
+	out := outBuf.String()
+	if strings.Contains(out, "ice debug") {
+		t.Errorf("Expected ice debug to be dropped after reload, got %q", out)
+	}
+	for _, expected := range []string{"sctp info", "dtls trace"} {
+		if !strings.Contains(out, expected) {
+			t.Errorf("Expected to find %q in %q, but didn't", expected, out)
+		}
+	}
+	if f.DefaultLogLevel != logging.LogLevelInfo {
+		t.Errorf("Expected default level %s, got %s", logging.LogLevelInfo, f.DefaultLogLevel)
+	}
+
+	t.Setenv("PION_LOG_INFO", "")
+	f.ReloadFromEnv()
+	if f.DefaultLogLevel != logging.LogLevelError {
+		t.Errorf("Expected default level %s, got %s", logging.LogLevelError, f.DefaultLogLevel)
+	}
+}
+
+func TestReloadOnSignal(t *testing.T) {
+	t.Setenv("PION_LOG_DEBUG", "")
+	t.Setenv("PION_LOG_INFO", "")
+
+	self, err := os.FindProcess(os.Getpid())
+	if err != nil {
+		t.Fatal(err)
+	}
+	received := make(chan os.Signal, 1)
+	signal.Notify(received, os.Interrupt)
+	defer signal.Stop(received)
+
+	f := logging.NewDefaultLoggerFactory()
+	f.Writer = io.Discard
+	t.Setenv("PION_LOG_INFO", "all")
+
+	stop := f.ReloadOnSignal()
+	defer stop()
+	if err := self.Signal(os.Interrupt); err != nil {
+		t.Skipf("Unable to send signal: %v", err)
+	}
+	<-received
+	time.Sleep(10 * time.Millisecond)
+	if level := f.EffectiveLevel("ice"); level != logging.LogLevelError {
+		t.Errorf("Expected ReloadOnSignal without signals to watch nothing, got level %s", level)
+	}
+
+	stop = f.ReloadOnSignal(os.Interrupt)
+	defer stop()
+	if err := self.Signal(os.Interrupt); err != nil {
+		t.Fatal(err)
+	}
+	<-received
+	deadline := time.Now().Add(5 * time.Second)
+	for f.EffectiveLevel("ice") != logging.LogLevelInfo {
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected level %s after signal, got %s", logging.LogLevelInfo, f.EffectiveLevel("ice"))
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestOnLevelChange(t *testing.T) {
+	t.Setenv("PION_LOG_DEBUG", "")
+	t.Setenv("PION_LOG_INFO", "")
+
+	f := logging.NewDefaultLoggerFactory()
+	f.Writer = io.Discard
+	f.NewLogger("ice")
+	f.NewLogger("sctp")
+
+	var changes []string
+	f.OnLevelChange(func(scope string, oldLevel, newLevel logging.LogLevel) {
+		changes = append(changes, fmt.Sprintf("%s:%s->%s", scope, oldLevel, newLevel))
+	})
+	expectChanges := func(expected ...string) {
+		t.Helper()
+		if strings.Join(changes, ",") != strings.Join(expected, ",") {
+			t.Errorf("Expected changes %v, got %v", expected, changes)
+		}
+		changes = nil
+	}
+
+	f.SetScopeLevel("ice", logging.LogLevelDebug)
+	expectChanges("ice:Error->Debug")
+
+	f.SetScopeLevel("ice", logging.LogLevelDebug)
+	expectChanges()
+
+	f.SetScopeLevel("dtls", logging.LogLevelTrace)
+	expectChanges("dtls:Error->Trace")
+
+	f.SetDefaultLogLevel(logging.LogLevelWarn)
+	expectChanges("sctp:Error->Warn")
+
+	t.Setenv("PION_LOG_INFO", "sctp")
+	f.ReloadFromEnv()
+	expectChanges("sctp:Warn->Info")
+
+	rec := serveLevels(t, f.ServeLevelsHTTP(), http.MethodPut, "/?scope=ice&level=trace")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Unexpected status %d: %s", rec.Code, rec.Body.String())
+	}
+	expectChanges("ice:Debug->Trace")
+}
+
+func TestWithLevelFromEnv(t *testing.T) {
+	t.Setenv("PION_LOG_DEBUG", "ICE,dtls")
+	t.Setenv("PION_LOG_WARN", "")
+	t.Setenv("PION_LOG_INFO", "")
+
+	newLogger := func(scope string) *logging.DefaultLeveledLogger {
+		return logging.NewDefaultLeveledLoggerForScope(scope, logging.LogLevelError, io.Discard).WithLevelFromEnv(scope)
+	}
+	if !newLogger("ice").Enabled(logging.LogLevelDebug) {
+		t.Error("Expected ice to be at debug level from the environment")
+	}
+	if newLogger("sctp").Enabled(logging.LogLevelWarn) {
+		t.Error("Expected sctp to keep its level without a matching variable")
+	}
+
+	t.Setenv("PION_LOG_WARN", "all")
+	if !newLogger("sctp").Enabled(logging.LogLevelWarn) {
+		t.Error("Expected sctp to be at warn level from the default")
+	}
+	if !newLogger("dtls").Enabled(logging.LogLevelDebug) {
+		t.Error("Expected the scope variable to win over the default")
+	}
+}
+
+func TestFactoryLoggerOwnLevel(t *testing.T) {
+	f := &logging.DefaultLoggerFactory{Writer: io.Discard, DefaultLogLevel: logging.LogLevelWarn}
+
+	newLogger := func() *logging.DefaultLeveledLogger {
+		t.Helper()
+		logger, ok := f.NewLogger("sctp").(*logging.DefaultLeveledLogger)
+		if !ok {
+			t.Fatal("Invalid logger type")
+		}
+		return logger
+	}
+
+	a := newLogger()
+	a.SetLevel(logging.LogLevelDebug)
+	b := newLogger()
+	if !a.Enabled(logging.LogLevelDebug) {
+		t.Error("Expected SetLevel to survive NewLogger for the same scope")
+	}
+	if b.Enabled(logging.LogLevelInfo) {
+		t.Error("Expected SetLevel on one logger not to change another")
+	}
+
+	b.SetLevel(logging.LogLevelTrace)
+	if a.Enabled(logging.LogLevelTrace) {
+		t.Error("Expected SetLevel on one logger not to change another")
+	}
+
+	c := newLogger()
+	f.SetScopeLevel("sctp", logging.LogLevelError)
+	if !c.Enabled(logging.LogLevelError) || c.Enabled(logging.LogLevelWarn) {
+		t.Error("Expected factory level changes to reach issued loggers")
+	}
+	if !a.Enabled(logging.LogLevelDebug) || !b.Enabled(logging.LogLevelTrace) {
+		t.Error("Expected factory level changes to skip loggers with their own level")
+	}
+}
+
+func TestFactoryUncomparableWriter(t *testing.T) {
+	var outBuf bytes.Buffer
+	f := &logging.DefaultLoggerFactory{
+		Writer:          writerFunc(outBuf.Write),
+		DefaultLogLevel: logging.LogLevelInfo,
+	}
+
+	f.NewLogger("ice").Info("first")
+	f.NewLogger("dtls").Info("second")
+	if strings.Count(outBuf.String(), "\n") != 2 {
+		t.Errorf("Expected 2 records, got %q", outBuf.String())
+	}
+}
+
+type wrappedWriter struct {
+	inner io.Writer
+}
+
+func (w wrappedWriter) Write(p []byte) (int, error) {
+	return w.inner.Write(p)
+}
+
+func TestFactoryWrappedUncomparableWriter(t *testing.T) {
+	var outBuf bytes.Buffer
+	f := &logging.DefaultLoggerFactory{
+		Writer:          wrappedWriter{inner: writerFunc(outBuf.Write)},
+		DefaultLogLevel: logging.LogLevelInfo,
+	}
+
+	f.NewLogger("ice").Info("first")
+	f.NewLogger("dtls").Info("second")
+	if strings.Count(outBuf.String(), "\n") != 2 {
+		t.Errorf("Expected 2 records, got %q", outBuf.String())
+	}
+}
+
+func TestIsNoop(t *testing.T) {
+	logger := logging.
+		NewDefaultLeveledLoggerForScope("testIsNoop", logging.LogLevelDisabled, os.Stderr)
+	if !logger.IsNoop() {
+		t.Error("Expected disabled logger to be a noop")
+	}
+
+	for _, level := range []logging.LogLevel{
+		logging.LogLevelError, logging.LogLevelWarn, logging.LogLevelInfo,
+		logging.LogLevelDebug, logging.LogLevelTrace,
+	} {
+		logger.SetLevel(level)
+		if logger.IsNoop() {
+			t.Errorf("Expected logger at level %s not to be a noop", level)
+		}
+	}
+}
+
+func TestLogStateTable(t *testing.T) {
+	var outBuf bytes.Buffer
+	logger := logging.
+		NewDefaultLeveledLoggerForScope("testStateTable", logging.LogLevelInfo, &outBuf)
+
+	table := map[string][]string{
+		"new":       {"checking", "closed"},
+		"checking":  {"connected", "failed"},
+		"connected": {"disconnected"},
+		"closed":    nil,
+	}
+	expected := "state table: checking -> [connected, failed]; closed -> []; " +
+		"connected -> [disconnected]; new -> [checking, closed]\n"
+
+	for i := 0; i < 3; i++ {
+		outBuf.Reset()
+		logger.LogStateTable(logging.LogLevelInfo, table)
+		if !strings.HasSuffix(outBuf.String(), expected) {
+			t.Errorf("Expected %q to end with %q", outBuf.String(), expected)
+		}
+	}
+
+	outBuf.Reset()
+	logger.LogStateTable(logging.LogLevelDebug, table)
+	if outBuf.Len() > 0 {
+		t.Error("State table was logged when it shouldn't have been")
+	}
+}
+
+func TestFactoryTimestamp(t *testing.T) {
+	var outBuf bytes.Buffer
+	f := logging.DefaultLoggerFactory{
+		Writer:           &outBuf,
+		DefaultLogLevel:  logging.LogLevelTrace,
+		DisableTimestamp: true,
+	}
+
+	logger := f.NewLogger("ice")
+	logger.Info("no time")
+	logger.Trace("no time")
+	if expected := "ice INFO: no time\n"; !strings.HasPrefix(outBuf.String(), expected) {
+		t.Errorf("Expected %q to start with %q", outBuf.String(), expected)
+	}
+	if !strings.Contains(outBuf.String(), "\nice TRACE: logging_test.go:") {
+		t.Errorf("Expected trace record without timestamp in %q", outBuf.String())
+	}
+
+	outBuf.Reset()
+	f.DisableTimestamp = false
+	f.TimestampLayout = time.RFC3339Nano
+	f.NewLogger("ice").Warn("with time")
+
+	line := strings.TrimPrefix(outBuf.String(), "ice WARNING: ")
+	timestamp, msg, _ := strings.Cut(line, " ")
+	if _, err := time.Parse(time.RFC3339Nano, timestamp); err != nil {
+		t.Errorf("Expected a parsable timestamp in %q: %v", outBuf.String(), err)
+	}
+	if msg != "with time\n" {
+		t.Errorf("Unexpected message %q", msg)
+	}
+}
+
+func parseUptime(t *testing.T, line string) time.Duration {
+	t.Helper()
+
+	_, uptime, found := strings.Cut(strings.TrimSpace(line), " uptime=")
+	if !found {
+		t.Fatalf("Expected uptime field in %q", line)
+	}
+	d, err := time.ParseDuration(uptime)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
+func TestWithUptime(t *testing.T) {
+	var outBuf bytes.Buffer
+	logger := logging.
+		NewDefaultLeveledLoggerForScope("testUptime", logging.LogLevelInfo, &outBuf).
+		WithUptime(time.Now().Add(-time.Hour))
+
+	logger.Info("first")
+	first := parseUptime(t, outBuf.String())
+	time.Sleep(2 * time.Millisecond)
+
+	outBuf.Reset()
+	logger.Infof("second %d", 2)
+	second := parseUptime(t, outBuf.String())
+
+	if first < time.Hour || second <= first {
+		t.Errorf("Expected uptime to start at an hour and increase, got %s then %s", first, second)
+	}
+
+	outBuf.Reset()
+	f := logging.DefaultLoggerFactory{Writer: &outBuf, DefaultLogLevel: logging.LogLevelInfo}
+	f.WithUptime().NewLogger("ice").Info("from factory")
+	if uptime := parseUptime(t, outBuf.String()); uptime <= 0 {
+		t.Errorf("Expected a positive uptime, got %s", uptime)
+	}
+}
+
+func TestWithTerminator(t *testing.T) {
+	var writes []string
+	logger := logging.
+		NewDefaultLeveledLoggerForScope("testTerminator", logging.LogLevelInfo, writerFunc(func(data []byte) (int, error) {
+			writes = append(writes, string(data))
+			return len(data), nil
+		})).
+		WithTimestamp("")
+
+	logger.Info("default")
+	logger.WithTerminator("\r\n").Infof("crlf %d", 1)
+	logger.WithScope("child").Warn("inherited")
+	logger.WithTerminator("").Error("framed")
+
+	expected := []string{
+		"testTerminator INFO: default\n",
+		"testTerminator INFO: crlf 1\r\n",
+		"testTerminator.child WARNING: inherited\r\n",
+		"testTerminator ERROR: framed",
+	}
+	if strings.Join(writes, "|") != strings.Join(expected, "|") {
+		t.Errorf("Expected one write per record %q, got %q", expected, writes)
+	}
+}
+
+func TestConcurrentWrites(t *testing.T) {
+	var outBuf bytes.Buffer
+	f := logging.DefaultLoggerFactory{
+		Writer:           &outBuf,
+		DefaultLogLevel:  logging.LogLevelTrace,
+		DisableTimestamp: true,
+	}
+
+	const goroutines, records = 8, 200
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		logger := f.NewLogger(fmt.Sprintf("scope%d", i))
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < records; j++ {
+				if j%2 == 0 {
+					logger.Infof("goroutine %d record %d", i, j)
+				} else {
+					logger.Warnf("goroutine %d record %d", i, j)
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	lines := strings.Split(strings.TrimSuffix(outBuf.String(), "\n"), "\n")
+	if len(lines) != goroutines*records {
+		t.Fatalf("Expected %d lines, got %d", goroutines*records, len(lines))
+	}
+	for _, line := range lines {
+		var scope, level string
+		var i, j int
+		if _, err := fmt.Sscanf(line, "scope%s %s goroutine %d record %d", &scope, &level, &i, &j); err != nil ||
+			fmt.Sprintf("scope%d", i) != "scope"+scope {
+			t.Fatalf("Interleaved line %q", line)
+		}
+	}
+}
+
+var errWriteFailed = errors.New("write failed")
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errWriteFailed
+}
+
+func captureStdio(t *testing.T, fn func()) string {
+	t.Helper()
+
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout, stderr := os.Stdout, os.Stderr
+	os.Stdout, os.Stderr = writer, writer
+	defer func() {
+		os.Stdout, os.Stderr = stdout, stderr
+	}()
+
+	fn()
+
+	if err = writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	out, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(out)
+}
+
+func TestWithErrorHandler(t *testing.T) {
+	var handled []error
+	f := logging.DefaultLoggerFactory{
+		Writer:          failingWriter{},
+		DefaultLogLevel: logging.LogLevelInfo,
+		ErrorHandler: func(err error) {
+			handled = append(handled, err)
+		},
+	}
+
+	out := captureStdio(t, func() {
+		logger := f.NewLogger("testErrorHandler")
+		logger.Info("lost")
+		logger.Errorf("lost %d", 2)
+		logger.Debug("filtered")
+	})
+	if out != "" {
+		t.Errorf("Expected nothing on stdout or stderr, got %q", out)
+	}
+	if len(handled) != 2 || !errors.Is(handled[0], errWriteFailed) || !errors.Is(handled[1], errWriteFailed) {
+		t.Errorf("Expected two write errors, got %v", handled)
+	}
+
+	out = captureStdio(t, func() {
+		logger := logging.NewDefaultLeveledLoggerForScope("testErrorHandler", logging.LogLevelInfo, failingWriter{})
+		logger.Info("lost")
+		logger.WithScope("child").Warn("lost")
+		logger.Error("lost")
+	})
+	if out != "Unable to log: write failed (further errors are not reported)\n" {
+		t.Errorf("Unexpected default error notice %q", out)
+	}
+
+	out = captureStdio(t, func() {
+		f := logging.DefaultLoggerFactory{Writer: failingWriter{}, DefaultLogLevel: logging.LogLevelInfo}
+		f.NewLogger("ice").Info("lost")
+		f.NewLogger("dtls").Info("lost")
+	})
+	if strings.Count(out, "Unable to log") != 1 {
+		t.Errorf("Expected a single notice per factory, got %q", out)
+	}
+}
+
+func TestAddHook(t *testing.T) {
+	var outBuf bytes.Buffer
+	var handled []error
+	logger := logging.
+		NewDefaultLeveledLoggerForScope("testAddHook", logging.LogLevelWarn, &outBuf).
+		WithErrorHandler(func(err error) {
+			handled = append(handled, err)
+		})
+
+	var calls []string
+	counts := map[logging.LogLevel]int{}
+	logger.AddHook(func(level logging.LogLevel, msg string) {
+		calls = append(calls, "first")
+		counts[level]++
+	})
+	logger.AddHook(func(logging.LogLevel, string) {
+		panic("broken hook")
+	})
+	logger.AddHook(func(_ logging.LogLevel, msg string) {
+		calls = append(calls, "third:"+msg)
+	})
+
+	logger.Debug("filtered")
+	logger.Info("filtered")
+	logger.Warn("warning")
+	logger.Errorf("error %d", 1)
+	logger.Error("error")
+	logger.WarnKV("hello", "k", "v")
+
+	if counts[logging.LogLevelError] != 2 || counts[logging.LogLevelWarn] != 2 || len(counts) != 2 {
+		t.Errorf("Unexpected hook counts %v", counts)
+	}
+	expected := []string{
+		"first", "third:warning", "first", "third:error 1", "first", "third:error", "first", "third:hello",
+	}
+	if strings.Join(calls, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected hooks to run in order %v, got %v", expected, calls)
+	}
+	if len(handled) != 4 || !strings.Contains(handled[0].Error(), "broken hook") {
+		t.Errorf("Expected panics to be reported, got %v", handled)
+	}
+	if strings.Count(outBuf.String(), "\n") != 4 {
+		t.Errorf("Expected 4 records, got %q", outBuf.String())
+	}
+}
+
+func TestAddFilter(t *testing.T) {
+	var outBuf bytes.Buffer
+	logger := logging.
+		NewDefaultLeveledLoggerForScope("testAddFilter", logging.LogLevelDebug, &outBuf).
+		WithTimestamp("").
+		WithCaller(false, 0)
+
+	var hooked []string
+	logger.AddHook(func(_ logging.LogLevel, msg string) {
+		hooked = append(hooked, msg)
+	})
+	logger.AddFilter(func(_ logging.LogLevel, msg string) bool {
+		return !strings.Contains(msg, "keepalive")
+	})
+	logger.AddFilter(func(level logging.LogLevel, _ string) bool {
+		return level != logging.LogLevelDebug
+	})
+
+	logger.Info("sent keepalive")
+	logger.Warnf("missed %d keepalives", 3)
+	logger.InfoKV("sent", "type", "keepalive")
+	logger.Debug("debug")
+	logger.WithScope("child").Error("keepalive timeout")
+	logger.Info("connected")
+	logger.Error("failed")
+
+	expected := "testAddFilter INFO: sent type=keepalive\ntestAddFilter INFO: connected\ntestAddFilter ERROR: failed\n"
+	if outBuf.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, outBuf.String())
+	}
+	if strings.Join(hooked, ",") != "sent,connected,failed" {
+		t.Errorf("Expected hooks to skip dropped records, got %v", hooked)
+	}
+}
+
+func TestWriter(t *testing.T) {
+	var outBuf bytes.Buffer
+	logger := logging.
+		NewDefaultLeveledLoggerForScope("testWriter", logging.LogLevelInfo, &outBuf).
+		WithTimestamp("")
+
+	writer := logger.Writer(logging.LogLevelWarn)
+	for _, chunk := range []string{"first line\nsecond", " line\r\n", "", "third", " line\nunterminated"} {
+		if n, err := io.WriteString(writer, chunk); err != nil || n != len(chunk) {
+			t.Fatalf("Unexpected write result %d, %v", n, err)
+		}
+	}
+
+	expected := "testWriter WARNING: first line\n" +
+		"testWriter WARNING: second line\n" +
+		"testWriter WARNING: third line\n"
+	if outBuf.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, outBuf.String())
+	}
+
+	outBuf.Reset()
+	logger.WithCaller(true, 0)
+	if _, err := logger.Writer(logging.LogLevelWarn).Write([]byte("caller\n")); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(outBuf.String(), "testWriter WARNING: logging_test.go:") {
+		t.Errorf("Expected the call site in %q", outBuf.String())
+	}
+
+	outBuf.Reset()
+	if _, err := io.WriteString(logger.Writer(logging.LogLevelDebug), "filtered\n"); err != nil {
+		t.Fatal(err)
+	}
+	if outBuf.Len() > 0 {
+		t.Errorf("Debug was logged when it shouldn't have been: %q", outBuf.String())
+	}
+}
+
+func TestStdLogger(t *testing.T) {
+	var outBuf bytes.Buffer
+	logger := logging.
+		NewDefaultLeveledLoggerForScope("testStdLogger", logging.LogLevelInfo, &outBuf).
+		WithTimestamp(time.RFC3339)
+
+	stdlog := logger.StdLogger(logging.LogLevelError)
+	stdlog.Println("x")
+	stdlog.Printf("y %d", 1)
+
+	lines := strings.Split(strings.TrimSuffix(outBuf.String(), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 records, got %q", outBuf.String())
+	}
+	for i, msg := range []string{"x", "y 1"} {
+		fields := strings.Fields(lines[i])
+		if len(fields) < 4 || fields[0] != "testStdLogger" || fields[1] != "ERROR:" ||
+			strings.Join(fields[3:], " ") != msg {
+			t.Errorf("Unexpected record %q", lines[i])
+		}
+		if _, err := time.Parse(time.RFC3339, fields[2]); err != nil {
+			t.Errorf("Expected a single timestamp in %q: %v", lines[i], err)
+		}
+	}
+
+	outBuf.Reset()
+	logger.WithCaller(true, 0)
+	stdlog.Print("caller")
+	if !strings.Contains(outBuf.String(), " logging_test.go:") {
+		t.Errorf("Expected the call site in %q", outBuf.String())
+	}
+
+	outBuf.Reset()
+	logger.StdLogger(logging.LogLevelDebug).Println("filtered")
+	if outBuf.Len() > 0 {
+		t.Errorf("Debug was logged when it shouldn't have been: %q", outBuf.String())
+	}
+}
+
+func TestScopeCaseInsensitive(t *testing.T) {
+	t.Setenv("PION_LOG_DEBUG", "ice")
+
+	f := logging.NewDefaultLoggerFactory()
+	f.ScopeLevels["DTLS"] = logging.LogLevelDebug
+
+	for _, scope := range []string{"ICE", "Ice", "dtls"} {
+		logger, ok := f.NewLogger(scope).(*logging.DefaultLeveledLogger)
+		if !ok {
+			t.Fatal("Invalid logger type")
+		}
+		testDebugLevel(t, logger)
+	}
+	if source := f.LevelSource("ICE"); source != logging.LevelSourceEnv {
+		t.Errorf("Expected level source %q, got %q", logging.LevelSourceEnv, source)
+	}
+
+	logger, ok := f.NewLogger("SCTP").(*logging.DefaultLeveledLogger)
+	if !ok {
+		t.Fatal("Invalid logger type")
+	}
+	testNoDebugLevel(t, logger)
+}
+
+func TestSetScopeLevelCase(t *testing.T) {
+	t.Setenv("PION_LOG_DEBUG", "ice")
+
+	f := logging.NewDefaultLoggerFactory()
+	f.Writer = io.Discard
+	logger, ok := f.NewLogger("ICE").(*logging.DefaultLeveledLogger)
+	if !ok {
+		t.Fatal("Invalid logger type")
+	}
+
+	var changes []string
+	f.OnLevelChange(func(scope string, oldLevel, newLevel logging.LogLevel) {
+		changes = append(changes, fmt.Sprintf("%s:%s->%s", scope, oldLevel, newLevel))
+	})
+	f.SetScopeLevel("ICE", logging.LogLevelWarn)
+
+	if level := f.EffectiveLevel("ICE"); level != logging.LogLevelWarn {
+		t.Errorf("Expected effective level %s, got %s", logging.LogLevelWarn, level)
+	}
+	if logger.Enabled(logging.LogLevelDebug) || !logger.Enabled(logging.LogLevelWarn) {
+		t.Error("Expected the issued logger to follow the new level")
+	}
+	if scopes := f.Scopes(); len(scopes) != 1 || scopes["ice"] != logging.LogLevelWarn {
+		t.Errorf("Expected a single ice scope at %s, got %v", logging.LogLevelWarn, scopes)
+	}
+	if strings.Join(changes, ",") != "ice:Debug->Warn" {
+		t.Errorf("Unexpected changes %v", changes)
+	}
+}
+
+func TestEnvLevelsMostVerboseWins(t *testing.T) {
+	t.Setenv("PION_LOG_ERROR", "all")
+	t.Setenv("PION_LOG_DEBUG", "all")
+	t.Setenv("PION_LOG_INFO", "all")
+	t.Setenv("PION_LOG_TRACE", "ice")
+	t.Setenv("PION_LOG_WARN", "ice")
+
+	// Variables are visited in map order, so repeat to cover several orders
+	for i := 0; i < 20; i++ {
+		f := logging.NewDefaultLoggerFactory()
+		if f.DefaultLogLevel != logging.LogLevelDebug {
+			t.Fatalf("Expected default level %s, got %s", logging.LogLevelDebug, f.DefaultLogLevel)
+		}
+		if f.ScopeLevels["ice"] != logging.LogLevelTrace {
+			t.Fatalf("Expected ice level %s, got %s", logging.LogLevelTrace, f.ScopeLevels["ice"])
+		}
+	}
+}
+
+func TestEnabled(t *testing.T) {
+	levels := []logging.LogLevel{
+		logging.LogLevelDisabled, logging.LogLevelError, logging.LogLevelWarn,
+		logging.LogLevelInfo, logging.LogLevelDebug, logging.LogLevelTrace,
+	}
+	logger := logging.
+		NewDefaultLeveledLoggerForScope("testEnabled", logging.LogLevelDisabled, os.Stderr)
+
+	for _, loggerLevel := range levels {
+		logger.SetLevel(loggerLevel)
+		for _, level := range levels {
+			expected := level != logging.LogLevelDisabled && level <= loggerLevel
+			if enabled := logger.Enabled(level); enabled != expected {
+				t.Errorf("Logger at %s: expected Enabled(%s) to be %v", loggerLevel, level, expected)
+			}
+		}
+	}
+}
+
+func TestWithScope(t *testing.T) {
+	var outBuf bytes.Buffer
+	parent := logging.
+		NewDefaultLeveledLoggerForScope("conn", logging.LogLevelInfo, &outBuf).
+		WithTimestamp("")
+	child := parent.WithScope("candidate")
+	grandchild := child.WithScope("host")
+
+	child.Info("gathered")
+	grandchild.Warn("failed")
+	if expected := "conn.candidate INFO: gathered\nconn.candidate.host WARNING: failed\n"; outBuf.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, outBuf.String())
+	}
+
+	outBuf.Reset()
+	child.Debug("filtered")
+	parent.SetLevel(logging.LogLevelDebug)
+	grandchild.Debug("shared level")
+	if !strings.Contains(outBuf.String(), "conn.candidate.host DEBUG: ") ||
+		!strings.Contains(outBuf.String(), "shared level") || strings.Contains(outBuf.String(), "filtered") {
+		t.Errorf("Expected level to be shared with the parent, got %q", outBuf.String())
+	}
+}
+
+func TestWithScopeCustomLoggers(t *testing.T) {
+	var outBuf, infoBuf bytes.Buffer
+	parent := logging.
+		NewDefaultLeveledLoggerForScope("conn", logging.LogLevelInfo, &outBuf).
+		WithInfoLogger(log.New(&infoBuf, "conn INFO: ", 0))
+
+	parent.WithScope("candidate").Info("gathered")
+	if expected := "conn.candidate INFO: gathered\n"; infoBuf.String() != expected || outBuf.Len() > 0 {
+		t.Errorf("Expected %q on the custom output, got %q and %q", expected, infoBuf.String(), outBuf.String())
+	}
+}
+
+func TestEffectiveLevel(t *testing.T) {
+	f := logging.DefaultLoggerFactory{
+		Writer:          io.Discard,
+		DefaultLogLevel: logging.LogLevelWarn,
+		ScopeLevels: map[string]logging.LogLevel{
+			"ice":  logging.LogLevelDebug,
+			"DTLS": logging.LogLevelTrace,
+		},
+	}
+	f.NewLogger("sctp")
+	f.NewLogger("Ice")
+
+	for scope, expected := range map[string]logging.LogLevel{
+		"ice":     logging.LogLevelDebug,
+		"dtls":    logging.LogLevelTrace,
+		"sctp":    logging.LogLevelWarn,
+		"unknown": logging.LogLevelWarn,
+	} {
+		if level := f.EffectiveLevel(scope); level != expected {
+			t.Errorf("Expected scope %q to resolve to %s, got %s", scope, expected, level)
+		}
+	}
+
+	expected := map[string]logging.LogLevel{
+		"ice":  logging.LogLevelDebug,
+		"DTLS": logging.LogLevelTrace,
+		"sctp": logging.LogLevelWarn,
+	}
+	scopes := f.Scopes()
+	if len(scopes) != len(expected) {
+		t.Errorf("Expected scopes %v, got %v", expected, scopes)
+	}
+	for scope, level := range expected {
+		if scopes[scope] != level {
+			t.Errorf("Expected scope %q at %s, got %v", scope, level, scopes)
+		}
+	}
+}
+
+func TestScopeInOutput(t *testing.T) {
+	var outBuf bytes.Buffer
+	factory := &logging.DefaultLoggerFactory{
+		Writer:           &outBuf,
+		DefaultLogLevel:  logging.LogLevelInfo,
+		DisableTimestamp: true,
+	}
+	standalone := logging.NewDefaultLeveledLoggerForScope("sctp", logging.LogLevelInfo, &outBuf).WithTimestamp("")
+
+	factory.NewLogger("ice").Info("from factory")
+	standalone.Warn("from constructor")
+
+	expected := "ice INFO: from factory\nsctp WARNING: from constructor\n"
+	if outBuf.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, outBuf.String())
+	}
+}
+
+func TestDisabledFormatting(t *testing.T) {
+	logger := logging.
+		NewDefaultLeveledLoggerForScope("testDisabled", logging.LogLevelError, io.Discard).
+		WithStrictFormat(true)
+	arg := &countingStringer{}
+
+	logger.Tracef("trace %s", arg)
+	logger.Debugf("debug %s", arg)
+	logger.Infof("info %s", arg)
+	logger.Warnf("warn %s", arg)
+	logger.Logf(logging.LogLevelDebug, "log %s", arg)
+	if arg.calls != 0 {
+		t.Errorf("Expected disabled records not to be formatted, formatted %d times", arg.calls)
+	}
+
+	logger.Errorf("error %s", arg)
+	if arg.calls != 1 {
+		t.Errorf("Expected an enabled record to be formatted once, formatted %d times", arg.calls)
+	}
+}
+
+func BenchmarkDisabledFormatting(b *testing.B) {
+	logger := logging.
+		NewDefaultLeveledLoggerForScope("benchmarkDisabled", logging.LogLevelError, io.Discard)
+	arg := &countingStringer{}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		logger.Tracef("trace %s", arg)
+		logger.Debugf("debug %s", arg)
+		logger.Infof("info %s", arg)
+		logger.Warnf("warn %s", arg)
+	}
+}
@@ -0,0 +1,122 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package logging
+
+import "io"
+
+// MultiLoggerFactory creates loggers which forward every call to a logger
+// from each of Factories, e.g. to log to the console and to a file at the
+// same time. Each factory applies its own levels.
+type MultiLoggerFactory struct {
+	Factories []LoggerFactory
+}
+
+// NewMultiLoggerFactory returns a MultiLoggerFactory fanning out to factories
+func NewMultiLoggerFactory(factories ...LoggerFactory) *MultiLoggerFactory {
+	return &MultiLoggerFactory{Factories: factories}
+}
+
+// NewLogger returns a LeveledLogger forwarding to a logger for the given
+// scope from each factory
+func (f *MultiLoggerFactory) NewLogger(scope string) LeveledLogger {
+	loggers := make(multiLogger, 0, len(f.Factories))
+	for _, factory := range f.Factories {
+		logger := factory.NewLogger(scope)
+		skipCallers(logger, 1)
+		loggers = append(loggers, logger)
+	}
+	return loggers
+}
+
+// Flush flushes every factory that buffers records and returns the first error
+func (f *MultiLoggerFactory) Flush() error {
+	var firstErr error
+	for _, factory := range f.Factories {
+		if err := flushWriter(factory); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// Close closes every factory that holds resources and returns the first error
+func (f *MultiLoggerFactory) Close() error {
+	var firstErr error
+	for _, factory := range f.Factories {
+		if closer, ok := factory.(io.Closer); ok {
+			if err := closer.Close(); err != nil && firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	return firstErr
+}
+
+type multiLogger []LeveledLogger
+
+func (l multiLogger) addCallerSkip(skip int) {
+	for _, logger := range l {
+		skipCallers(logger, skip)
+	}
+}
+
+func (l multiLogger) Trace(msg string) {
+	for _, logger := range l {
+		logger.Trace(msg)
+	}
+}
+
+func (l multiLogger) Tracef(format string, args ...interface{}) {
+	for _, logger := range l {
+		logger.Tracef(format, args...)
+	}
+}
+
+func (l multiLogger) Debug(msg string) {
+	for _, logger := range l {
+		logger.Debug(msg)
+	}
+}
+
+func (l multiLogger) Debugf(format string, args ...interface{}) {
+	for _, logger := range l {
+		logger.Debugf(format, args...)
+	}
+}
+
+func (l multiLogger) Info(msg string) {
+	for _, logger := range l {
+		logger.Info(msg)
+	}
+}
+
+func (l multiLogger) Infof(format string, args ...interface{}) {
+	for _, logger := range l {
+		logger.Infof(format, args...)
+	}
+}
+
+func (l multiLogger) Warn(msg string) {
+	for _, logger := range l {
+		logger.Warn(msg)
+	}
+}
+
+func (l multiLogger) Warnf(format string, args ...interface{}) {
+	for _, logger := range l {
+		logger.Warnf(format, args...)
+	}
+}
+
+func (l multiLogger) Error(msg string) {
+	for _, logger := range l {
+		logger.Error(msg)
+	}
+}
+
+func (l multiLogger) Errorf(format string, args ...interface{}) {
+	for _, logger := range l {
+		logger.Errorf(format, args...)
+	}
+}
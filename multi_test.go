@@ -0,0 +1,69 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package logging_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/pion/logging"
+)
+
+func TestMultiLoggerFactory(t *testing.T) {
+	var outBuf closableBuffer
+	sink := &captureSink{}
+	f := logging.NewMultiLoggerFactory(
+		&logging.DefaultLoggerFactory{
+			Writer:           &outBuf,
+			DefaultLogLevel:  logging.LogLevelWarn,
+			DisableTimestamp: true,
+		},
+		logging.NewRecordLoggerFactory(sink, logging.LogLevelDebug),
+	)
+
+	logger := f.NewLogger("ice")
+	logger.Debugf("checking pair %d", 1)
+	logger.Warn("pair failed")
+
+	var debugBuf bytes.Buffer
+	sampled := logging.NewSamplingLoggerFactory(&logging.DefaultLoggerFactory{
+		Writer:          &debugBuf,
+		DefaultLogLevel: logging.LogLevelDebug,
+	}, nil)
+	logging.NewMultiLoggerFactory(sampled).NewLogger("dtls").Debug("handshake")
+	if !strings.Contains(debugBuf.String(), " multi_test.go:") {
+		t.Errorf("Expected the call site in %q", debugBuf.String())
+	}
+
+	if expected := "ice WARNING: pair failed\n"; outBuf.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, outBuf.String())
+	}
+	if len(sink.records) != 2 || sink.records[0].Message != "checking pair 1" ||
+		sink.records[1].Level != logging.LogLevelWarn || sink.records[1].Scope != "ice" {
+		t.Errorf("Unexpected records %+v", sink.records)
+	}
+
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if outBuf.closed != 1 {
+		t.Errorf("Expected the writer to be closed once, got %d", outBuf.closed)
+	}
+}
+
+func TestMultiLoggerFactoryEmpty(t *testing.T) {
+	f := logging.NewMultiLoggerFactory()
+	f.NewLogger("ice").Error("dropped")
+	if err := f.Flush(); err != nil {
+		t.Error(err)
+	}
+
+	var outBuf bytes.Buffer
+	f.Factories = append(f.Factories, &logging.DefaultLoggerFactory{Writer: &outBuf, DefaultLogLevel: logging.LogLevelError})
+	f.NewLogger("ice").Error("kept")
+	if !bytes.Contains(outBuf.Bytes(), []byte(" kept\n")) {
+		t.Errorf("Expected the record in %q", outBuf.String())
+	}
+}
@@ -0,0 +1,252 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package logging
+
+import (
+	"context"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	defaultNetworkBufferSize   = 1 << 20
+	defaultNetworkMinBackoff   = 100 * time.Millisecond
+	defaultNetworkMaxBackoff   = 10 * time.Second
+	defaultNetworkDialTimeout  = 5 * time.Second
+	defaultNetworkWriteTimeout = 5 * time.Second
+)
+
+// NetworkWriter forwards newline framed log records to a remote collector.
+// Records are buffered up to a byte limit and sent by a background goroutine,
+// so that logging never waits on the network. The connection is dialed
+// lazily after the first write and re-established with an exponential
+// backoff. Records that do not fit into the buffer are dropped and counted.
+type NetworkWriter struct {
+	network string
+	address string
+
+	mu         sync.Mutex
+	conn       net.Conn
+	pending    [][]byte
+	pendingLen int
+	bufferSize int
+	minBackoff time.Duration
+	maxBackoff time.Duration
+	closed     bool
+
+	start  sync.Once
+	wake   chan struct{}
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	dropped uint64
+}
+
+// NewNetworkWriter returns a NetworkWriter for the given network and address,
+// as accepted by net.Dial
+func NewNetworkWriter(network, address string) *NetworkWriter {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &NetworkWriter{
+		network:    network,
+		address:    address,
+		bufferSize: defaultNetworkBufferSize,
+		minBackoff: defaultNetworkMinBackoff,
+		maxBackoff: defaultNetworkMaxBackoff,
+		wake:       make(chan struct{}, 1),
+		ctx:        ctx,
+		cancel:     cancel,
+		done:       make(chan struct{}),
+	}
+}
+
+// WithBufferSize is a chainable configuration function which sets the maximum
+// number of bytes buffered while disconnected
+func (w *NetworkWriter) WithBufferSize(size int) *NetworkWriter {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.bufferSize = size
+	return w
+}
+
+// WithBackoff is a chainable configuration function which sets the initial and
+// maximum delay between reconnection attempts
+func (w *NetworkWriter) WithBackoff(minBackoff, maxBackoff time.Duration) *NetworkWriter {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.minBackoff = minBackoff
+	w.maxBackoff = maxBackoff
+	return w
+}
+
+// Write queues data for the collector. It never blocks on the network and
+// never returns an error, so that logging is not interrupted by an
+// unavailable collector; use Dropped to observe lost records.
+func (w *NetworkWriter) Write(data []byte) (int, error) {
+	record := make([]byte, len(data), len(data)+1)
+	copy(record, data)
+	if len(record) == 0 || record[len(record)-1] != '\n' {
+		record = append(record, '\n')
+	}
+
+	w.mu.Lock()
+	if w.closed || w.pendingLen+len(record) > w.bufferSize {
+		w.mu.Unlock()
+		atomic.AddUint64(&w.dropped, 1)
+		return len(data), nil
+	}
+	w.pending = append(w.pending, record)
+	w.pendingLen += len(record)
+	w.mu.Unlock()
+
+	w.start.Do(func() {
+		go w.run()
+	})
+	select {
+	case w.wake <- struct{}{}:
+	default:
+	}
+	return len(data), nil
+}
+
+// Dropped returns the number of records dropped because the buffer was full
+// or the writer was closed
+func (w *NetworkWriter) Dropped() uint64 {
+	return atomic.LoadUint64(&w.dropped)
+}
+
+// Close stops the background goroutine and closes the connection to the
+// collector. Buffered records that were not delivered are discarded.
+func (w *NetworkWriter) Close() error {
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
+		return nil
+	}
+	w.closed = true
+	w.pending = nil
+	w.pendingLen = 0
+	conn := w.conn
+	w.mu.Unlock()
+
+	w.cancel()
+	w.start.Do(func() {
+		close(w.done)
+	})
+	var err error
+	if conn != nil {
+		// also interrupts a write in progress
+		err = conn.Close()
+	}
+	<-w.done
+	return err
+}
+
+// run delivers the buffered records until the writer is closed
+func (w *NetworkWriter) run() {
+	defer close(w.done)
+
+	var backoff time.Duration
+	for {
+		if !w.hasPending() {
+			select {
+			case <-w.wake:
+				continue
+			case <-w.ctx.Done():
+				return
+			}
+		}
+
+		if err := w.send(); err == nil {
+			backoff = 0
+			continue
+		}
+
+		w.mu.Lock()
+		minBackoff, maxBackoff := w.minBackoff, w.maxBackoff
+		w.mu.Unlock()
+		switch {
+		case backoff == 0:
+			backoff = minBackoff
+		case backoff*2 > maxBackoff:
+			backoff = maxBackoff
+		default:
+			backoff *= 2
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-timer.C:
+		case <-w.ctx.Done():
+			timer.Stop()
+			return
+		}
+	}
+}
+
+func (w *NetworkWriter) hasPending() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return len(w.pending) > 0
+}
+
+// send dials if needed and writes the buffered records, returning on the
+// first failure
+func (w *NetworkWriter) send() error {
+	w.mu.Lock()
+	conn := w.conn
+	w.mu.Unlock()
+
+	if conn == nil {
+		dialer := net.Dialer{Timeout: defaultNetworkDialTimeout}
+		var err error
+		if conn, err = dialer.DialContext(w.ctx, w.network, w.address); err != nil {
+			return err
+		}
+
+		w.mu.Lock()
+		if w.closed {
+			w.mu.Unlock()
+			return conn.Close()
+		}
+		w.conn = conn
+		w.mu.Unlock()
+	}
+
+	for {
+		w.mu.Lock()
+		if len(w.pending) == 0 || w.closed {
+			w.mu.Unlock()
+			return nil
+		}
+		record := w.pending[0]
+		w.mu.Unlock()
+
+		err := conn.SetWriteDeadline(time.Now().Add(defaultNetworkWriteTimeout))
+		n := 0
+		if err == nil {
+			n, err = conn.Write(record)
+		}
+
+		w.mu.Lock()
+		if w.closed {
+			w.mu.Unlock()
+			return nil
+		}
+		w.pendingLen -= n
+		if err != nil {
+			// Keep the unsent remainder and retry it once reconnected
+			w.pending[0] = record[n:]
+			w.conn = nil
+			w.mu.Unlock()
+			_ = conn.Close()
+			return err
+		}
+		w.pending[0] = nil
+		w.pending = w.pending[1:]
+		w.mu.Unlock()
+	}
+}
@@ -0,0 +1,199 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package logging_test
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/pion/logging"
+)
+
+func unusedTCPAddress(t *testing.T) string {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := listener.Addr().String()
+	if err = listener.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return addr
+}
+
+func readLine(t *testing.T, conn net.Conn, reader *bufio.Reader) string {
+	t.Helper()
+
+	if err := conn.SetReadDeadline(time.Now().Add(5 * time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		t.Fatal(err)
+	}
+	return line
+}
+
+func TestNetworkWriterBuffersUntilConnected(t *testing.T) {
+	addr := unusedTCPAddress(t)
+	writer := logging.NewNetworkWriter("tcp", addr).WithBackoff(time.Millisecond, 5*time.Millisecond)
+	defer func() { _ = writer.Close() }()
+
+	for i := 0; i < 3; i++ {
+		if _, err := fmt.Fprintf(writer, "buffered-%d", i); err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(2 * time.Millisecond)
+	}
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = listener.Close() }()
+
+	time.Sleep(10 * time.Millisecond)
+	if _, err = writer.Write([]byte("last\n")); err != nil {
+		t.Fatal(err)
+	}
+
+	conn, err := listener.Accept()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	reader := bufio.NewReader(conn)
+	for _, expected := range []string{"buffered-0\n", "buffered-1\n", "buffered-2\n", "last\n"} {
+		if line := readLine(t, conn, reader); line != expected {
+			t.Errorf("Expected %q, got %q", expected, line)
+		}
+	}
+	if dropped := writer.Dropped(); dropped != 0 {
+		t.Errorf("Expected no dropped records, got %d", dropped)
+	}
+}
+
+func TestNetworkWriterReconnects(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = listener.Close() }()
+
+	writer := logging.NewNetworkWriter("tcp", listener.Addr().String()).WithBackoff(time.Millisecond, 5*time.Millisecond)
+	defer func() { _ = writer.Close() }()
+
+	if _, err = writer.Write([]byte("first\n")); err != nil {
+		t.Fatal(err)
+	}
+	conn, err := listener.Accept()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if line := readLine(t, conn, bufio.NewReader(conn)); line != "first\n" {
+		t.Errorf("Expected %q, got %q", "first\n", line)
+	}
+	if err = conn.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	accepted := make(chan net.Conn, 1)
+	go func() {
+		c, acceptErr := listener.Accept()
+		if acceptErr == nil {
+			accepted <- c
+		}
+	}()
+
+	var reconnected net.Conn
+	deadline := time.After(5 * time.Second)
+	for i := 0; reconnected == nil; i++ {
+		select {
+		case reconnected = <-accepted:
+		case <-deadline:
+			t.Fatal("Writer did not reconnect")
+		case <-time.After(2 * time.Millisecond):
+			if _, err = fmt.Fprintf(writer, "retry-%d\n", i); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	defer func() { _ = reconnected.Close() }()
+
+	if _, err = writer.Write([]byte("after\n")); err != nil {
+		t.Fatal(err)
+	}
+	reader := bufio.NewReader(reconnected)
+	for {
+		if line := readLine(t, reconnected, reader); line == "after\n" {
+			break
+		}
+	}
+}
+
+func TestNetworkWriterDropsWhenFull(t *testing.T) {
+	writer := logging.NewNetworkWriter("tcp", unusedTCPAddress(t)).WithBufferSize(10)
+	defer func() { _ = writer.Close() }()
+
+	for i := 0; i < 3; i++ {
+		if _, err := writer.Write([]byte("1234567\n")); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if dropped := writer.Dropped(); dropped != 2 {
+		t.Errorf("Expected 2 dropped records, got %d", dropped)
+	}
+}
+
+func TestNetworkWriterDoesNotBlock(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = listener.Close() }()
+
+	// accept the connection but never read from it
+	accepted := make(chan net.Conn, 1)
+	go func() {
+		if conn, acceptErr := listener.Accept(); acceptErr == nil {
+			accepted <- conn
+		}
+	}()
+
+	writer := logging.NewNetworkWriter("tcp", listener.Addr().String())
+	if _, err = writer.Write([]byte("connect\n")); err != nil {
+		t.Fatal(err)
+	}
+	var conn net.Conn
+	select {
+	case conn = <-accepted:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Writer did not connect")
+	}
+	defer func() { _ = conn.Close() }()
+
+	record := make([]byte, 64<<10)
+	start := time.Now()
+	for i := 0; i < 1024; i++ {
+		if _, err = writer.Write(record); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected writes to a stalled collector not to block, took %v", elapsed)
+	}
+	if writer.Dropped() == 0 {
+		t.Error("Expected records to be dropped once the buffer is full")
+	}
+
+	if err = writer.Close(); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,50 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package logging
+
+// NoopLoggerFactory creates loggers that discard everything
+type NoopLoggerFactory struct{}
+
+// NewLogger returns a NoopLogger
+func (NoopLoggerFactory) NewLogger(string) LeveledLogger {
+	return NoopLogger{}
+}
+
+// NoopLogger is a LeveledLogger that discards every message. Calls made on
+// the concrete type don't allocate; calls through the LeveledLogger interface
+// may still allocate for the variadic arguments.
+type NoopLogger struct{}
+
+// Enabled always returns false
+func (NoopLogger) Enabled(LogLevel) bool { return false }
+
+// Trace does nothing
+func (NoopLogger) Trace(string) {}
+
+// Tracef does nothing
+func (NoopLogger) Tracef(string, ...interface{}) {}
+
+// Debug does nothing
+func (NoopLogger) Debug(string) {}
+
+// Debugf does nothing
+func (NoopLogger) Debugf(string, ...interface{}) {}
+
+// Info does nothing
+func (NoopLogger) Info(string) {}
+
+// Infof does nothing
+func (NoopLogger) Infof(string, ...interface{}) {}
+
+// Warn does nothing
+func (NoopLogger) Warn(string) {}
+
+// Warnf does nothing
+func (NoopLogger) Warnf(string, ...interface{}) {}
+
+// Error does nothing
+func (NoopLogger) Error(string) {}
+
+// Errorf does nothing
+func (NoopLogger) Errorf(string, ...interface{}) {}
@@ -0,0 +1,59 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package logging_test
+
+import (
+	"testing"
+
+	"github.com/pion/logging"
+)
+
+func logAllMethods(logger logging.LeveledLogger) {
+	logger.Trace("trace")
+	logger.Tracef("trace %d", 1)
+	logger.Debug("debug")
+	logger.Debugf("debug %d", 1)
+	logger.Info("info")
+	logger.Infof("info %d", 1)
+	logger.Warn("warn")
+	logger.Warnf("warn %d", 1)
+	logger.Error("error")
+	logger.Errorf("error %d", 1)
+}
+
+func TestNoopLoggerFactory(t *testing.T) {
+	logger := logging.NoopLoggerFactory{}.NewLogger("noop")
+
+	noop, ok := logger.(logging.NoopLogger)
+	if !ok {
+		t.Fatalf("Unexpected logger type %T", logger)
+	}
+	for level := logging.LogLevelDisabled; level <= logging.LogLevelTrace; level++ {
+		if noop.Enabled(level) {
+			t.Errorf("Expected level %s to be disabled", level)
+		}
+	}
+
+	out := captureStdio(t, func() {
+		logAllMethods(logger)
+	})
+	if out != "" {
+		t.Errorf("Expected no output, got %q", out)
+	}
+
+	if !raceEnabled {
+		if allocs := testing.AllocsPerRun(100, func() { noop.Debugf("packet %d", 1000) }); allocs != 0 {
+			t.Errorf("Expected no allocations, got %v", allocs)
+		}
+	}
+}
+
+func BenchmarkNoopLogger(b *testing.B) {
+	logger := logging.NoopLogger{}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		logger.Debugf("packet %d", i)
+	}
+}
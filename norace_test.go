@@ -0,0 +1,9 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !race
+// +build !race
+
+package logging_test
+
+const raceEnabled = false
@@ -0,0 +1,64 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package logging
+
+import "io"
+
+// Option configures the LoggerFactory returned by New
+type Option func(*DefaultLoggerFactory)
+
+// New returns a LoggerFactory configured from the PION_LOG_* variables and
+// then by opts, so options take precedence over the environment
+func New(opts ...Option) LoggerFactory {
+	factory := NewDefaultLoggerFactory()
+	for _, opt := range opts {
+		opt(factory)
+	}
+	return factory
+}
+
+// WithLevel sets the level of scopes without an explicit level
+func WithLevel(level LogLevel) Option {
+	return func(f *DefaultLoggerFactory) {
+		f.DefaultLogLevel = level
+	}
+}
+
+// WithScopeLevel sets the level of a single scope
+func WithScopeLevel(scope string, level LogLevel) Option {
+	return func(f *DefaultLoggerFactory) {
+		setScopeLevel(f.ScopeLevels, scope, level)
+	}
+}
+
+// WithWriter sets the output of every logger
+func WithWriter(writer io.Writer) Option {
+	return func(f *DefaultLoggerFactory) {
+		f.Writer = writer
+	}
+}
+
+// WithTimestampLayout formats record timestamps with the given time.Format
+// layout. An empty layout disables timestamps.
+func WithTimestampLayout(layout string) Option {
+	return func(f *DefaultLoggerFactory) {
+		f.TimestampLayout = layout
+		f.DisableTimestamp = layout == ""
+	}
+}
+
+// WithGlobalFields adds key/value pairs emitted on every record
+func WithGlobalFields(kv ...interface{}) Option {
+	return func(f *DefaultLoggerFactory) {
+		f.GlobalFields = append(f.GlobalFields, kv...)
+	}
+}
+
+// WithLevelLabels replaces the level names in record prefixes, e.g. with
+// ShortLevelLabels
+func WithLevelLabels(labels map[LogLevel]string) Option {
+	return func(f *DefaultLoggerFactory) {
+		f.LevelLabels = labels
+	}
+}
@@ -0,0 +1,48 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package logging_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pion/logging"
+)
+
+func TestNew(t *testing.T) {
+	t.Setenv("PION_LOG_TRACE", "all")
+
+	var outBuf bytes.Buffer
+	f := logging.New(
+		logging.WithLevel(logging.LogLevelWarn),
+		logging.WithScopeLevel("ice", logging.LogLevelDebug),
+		logging.WithWriter(&outBuf),
+		logging.WithTimestampLayout(""),
+	)
+
+	sctp := f.NewLogger("sctp")
+	sctp.Info("filtered")
+	sctp.Warn("warning")
+	ice := f.NewLogger("ice")
+	ice.Trace("filtered")
+	ice.Debugf("debug %d", 1)
+
+	expected := "sctp WARNING: warning\nice DEBUG: options_test.go:"
+	if out := outBuf.String(); !strings.HasPrefix(out, expected) || !strings.HasSuffix(out, ": debug 1\n") {
+		t.Errorf("Expected %q to start with %q", out, expected)
+	}
+
+	outBuf.Reset()
+	logging.New(
+		logging.WithWriter(&outBuf),
+		logging.WithTimestampLayout(time.RFC3339),
+	).NewLogger("dtls").Error("failed")
+
+	timestamp, msg, _ := strings.Cut(strings.TrimPrefix(outBuf.String(), "dtls ERROR: "), " ")
+	if _, err := time.Parse(time.RFC3339, timestamp); err != nil || msg != "failed\n" {
+		t.Errorf("Unexpected record %q", outBuf.String())
+	}
+}
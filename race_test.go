@@ -0,0 +1,10 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build race
+// +build race
+
+package logging_test
+
+// The race detector adds allocations of its own
+const raceEnabled = true
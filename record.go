@@ -0,0 +1,178 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package logging
+
+import (
+	"fmt"
+	"time"
+)
+
+// Field is a key/value pair attached to a Record
+type Field struct {
+	Key   string
+	Value interface{}
+}
+
+// Record is a log record in structured form, for programs that process
+// records in memory instead of parsing text
+type Record struct {
+	Scope   string
+	Level   LogLevel
+	Time    time.Time
+	Message string
+	// Fields holds the key/value pairs of the KV methods in call order
+	Fields []Field
+}
+
+// RecordSink receives the records of the loggers created by a
+// RecordLoggerFactory. Write may be called concurrently.
+type RecordSink interface {
+	Write(record Record)
+}
+
+// RecordLoggerFactory creates RecordLoggers handing their records to Sink.
+// Scopes in ScopeLevels are matched case insensitively.
+type RecordLoggerFactory struct {
+	Sink            RecordSink
+	DefaultLogLevel LogLevel
+	ScopeLevels     map[string]LogLevel
+}
+
+// NewRecordLoggerFactory returns a RecordLoggerFactory writing records at
+// level or more severe to sink
+func NewRecordLoggerFactory(sink RecordSink, level LogLevel) *RecordLoggerFactory {
+	return &RecordLoggerFactory{
+		Sink:            sink,
+		DefaultLogLevel: level,
+		ScopeLevels:     make(map[string]LogLevel),
+	}
+}
+
+// NewLogger returns a RecordLogger for the given scope
+func (f *RecordLoggerFactory) NewLogger(scope string) LeveledLogger {
+	level := f.DefaultLogLevel
+	if scopeLevel, found := lookupScopeLevel(f.ScopeLevels, scope); found {
+		level = scopeLevel
+	}
+	return &RecordLogger{scope: scope, level: level, sink: f.Sink}
+}
+
+// RecordLogger is a LeveledLogger emitting Records instead of text. The
+// formatting methods render their message with fmt.Sprintf; the KV methods
+// keep their key/value pairs as Fields.
+type RecordLogger struct {
+	scope string
+	level LogLevel
+	sink  RecordSink
+}
+
+// SetLevel sets the logger's logging level
+func (rl *RecordLogger) SetLevel(newLevel LogLevel) {
+	rl.level.Set(newLevel)
+}
+
+// Enabled reports whether records at the given level are emitted
+func (rl *RecordLogger) Enabled(level LogLevel) bool {
+	return level > LogLevelDisabled && rl.level.Get() >= level
+}
+
+func (rl *RecordLogger) write(level LogLevel, msg string, kv []interface{}) {
+	record := Record{Scope: rl.scope, Level: level, Time: time.Now(), Message: msg}
+	if len(kv) > 0 {
+		record.Fields = parseKV(kv)
+	}
+	rl.sink.Write(record)
+}
+
+func (rl *RecordLogger) logf(level LogLevel, format string, args []interface{}) {
+	if rl.Enabled(level) {
+		rl.write(level, fmt.Sprintf(format, args...), nil)
+	}
+}
+
+func (rl *RecordLogger) log(level LogLevel, msg string, kv []interface{}) {
+	if rl.Enabled(level) {
+		rl.write(level, msg, kv)
+	}
+}
+
+// Trace emits the preformatted message if the logger is at or below LogLevelTrace
+func (rl *RecordLogger) Trace(msg string) {
+	rl.log(LogLevelTrace, msg, nil)
+}
+
+// Tracef formats and emits a message if the logger is at or below LogLevelTrace
+func (rl *RecordLogger) Tracef(format string, args ...interface{}) {
+	rl.logf(LogLevelTrace, format, args)
+}
+
+// TraceKV emits msg with the key/value pairs in kv as Fields if the logger
+// is at or below LogLevelTrace
+func (rl *RecordLogger) TraceKV(msg string, kv ...interface{}) {
+	rl.log(LogLevelTrace, msg, kv)
+}
+
+// Debug emits the preformatted message if the logger is at or below LogLevelDebug
+func (rl *RecordLogger) Debug(msg string) {
+	rl.log(LogLevelDebug, msg, nil)
+}
+
+// Debugf formats and emits a message if the logger is at or below LogLevelDebug
+func (rl *RecordLogger) Debugf(format string, args ...interface{}) {
+	rl.logf(LogLevelDebug, format, args)
+}
+
+// DebugKV emits msg with the key/value pairs in kv as Fields if the logger
+// is at or below LogLevelDebug
+func (rl *RecordLogger) DebugKV(msg string, kv ...interface{}) {
+	rl.log(LogLevelDebug, msg, kv)
+}
+
+// Info emits the preformatted message if the logger is at or below LogLevelInfo
+func (rl *RecordLogger) Info(msg string) {
+	rl.log(LogLevelInfo, msg, nil)
+}
+
+// Infof formats and emits a message if the logger is at or below LogLevelInfo
+func (rl *RecordLogger) Infof(format string, args ...interface{}) {
+	rl.logf(LogLevelInfo, format, args)
+}
+
+// InfoKV emits msg with the key/value pairs in kv as Fields if the logger
+// is at or below LogLevelInfo
+func (rl *RecordLogger) InfoKV(msg string, kv ...interface{}) {
+	rl.log(LogLevelInfo, msg, kv)
+}
+
+// Warn emits the preformatted message if the logger is at or below LogLevelWarn
+func (rl *RecordLogger) Warn(msg string) {
+	rl.log(LogLevelWarn, msg, nil)
+}
+
+// Warnf formats and emits a message if the logger is at or below LogLevelWarn
+func (rl *RecordLogger) Warnf(format string, args ...interface{}) {
+	rl.logf(LogLevelWarn, format, args)
+}
+
+// WarnKV emits msg with the key/value pairs in kv as Fields if the logger
+// is at or below LogLevelWarn
+func (rl *RecordLogger) WarnKV(msg string, kv ...interface{}) {
+	rl.log(LogLevelWarn, msg, kv)
+}
+
+// Error emits the preformatted message if the logger is at or below LogLevelError
+func (rl *RecordLogger) Error(msg string) {
+	rl.log(LogLevelError, msg, nil)
+}
+
+// Errorf formats and emits a message if the logger is at or below LogLevelError
+func (rl *RecordLogger) Errorf(format string, args ...interface{}) {
+	rl.logf(LogLevelError, format, args)
+}
+
+// ErrorKV emits msg with the key/value pairs in kv as Fields if the logger
+// is at or below LogLevelError
+func (rl *RecordLogger) ErrorKV(msg string, kv ...interface{}) {
+	rl.log(LogLevelError, msg, kv)
+}
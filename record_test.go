@@ -0,0 +1,72 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package logging_test
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pion/logging"
+)
+
+type captureSink struct {
+	mu      sync.Mutex
+	records []logging.Record
+}
+
+func (s *captureSink) Write(record logging.Record) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records = append(s.records, record)
+}
+
+func TestRecordLogger(t *testing.T) {
+	sink := &captureSink{}
+	f := logging.NewRecordLoggerFactory(sink, logging.LogLevelInfo)
+	f.ScopeLevels["DTLS"] = logging.LogLevelError
+
+	before := time.Now()
+	ice, ok := f.NewLogger("ice").(*logging.RecordLogger)
+	if !ok {
+		t.Fatal("Invalid logger type")
+	}
+	calls := 0
+	port := func() interface{} {
+		calls++
+		return 5000
+	}
+	ice.DebugKV("filtered", "port", port)
+	ice.WarnKV("candidate gathered", "type", "host", "port", port, "dangling")
+	ice.Infof("state %s", "connected")
+	ice.Debug("filtered")
+	f.NewLogger("dtls").Warn("filtered")
+
+	if len(sink.records) != 2 {
+		t.Fatalf("Expected 2 records, got %+v", sink.records)
+	}
+
+	record := sink.records[0]
+	if record.Scope != "ice" || record.Level != logging.LogLevelWarn || record.Message != "candidate gathered" {
+		t.Errorf("Unexpected record %+v", record)
+	}
+	if record.Time.Before(before) {
+		t.Errorf("Expected the record time to be set, got %v", record.Time)
+	}
+	expected := []logging.Field{
+		{Key: "type", Value: "host"}, {Key: "port", Value: 5000}, {Key: "!BADKEY", Value: "dangling"},
+	}
+	if !reflect.DeepEqual(record.Fields, expected) {
+		t.Errorf("Expected fields %v, got %v", expected, record.Fields)
+	}
+	if calls != 1 {
+		t.Errorf("Expected the deferred value to be resolved once, got %d calls", calls)
+	}
+
+	record = sink.records[1]
+	if record.Level != logging.LogLevelInfo || record.Message != "state connected" || record.Fields != nil {
+		t.Errorf("Unexpected record %+v", record)
+	}
+}
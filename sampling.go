@@ -0,0 +1,169 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package logging
+
+import (
+	"hash/fnv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// samplingCounters is the number of counters per sampled level. Messages are
+// hashed into them, so unrelated messages may occasionally share a counter.
+const samplingCounters = 1024
+
+// SamplingPolicy logs the First occurrences of a message in every Interval,
+// and then only every Thereafter-th occurrence. A zero Thereafter drops every
+// message past the first ones.
+type SamplingPolicy struct {
+	Interval   time.Duration
+	First      uint64
+	Thereafter uint64
+}
+
+// SamplingLoggerFactory wraps another LoggerFactory and samples repeated
+// messages per level. Occurrences are counted per scope and message; for the
+// formatting methods the format string is the message.
+type SamplingLoggerFactory struct {
+	Factory  LoggerFactory
+	Policies map[LogLevel]SamplingPolicy
+
+	once     sync.Once
+	counters map[LogLevel]*[samplingCounters]samplingCounter
+}
+
+// NewSamplingLoggerFactory returns a SamplingLoggerFactory sampling the
+// loggers of factory according to policies. Levels without a policy are not
+// sampled.
+func NewSamplingLoggerFactory(factory LoggerFactory, policies map[LogLevel]SamplingPolicy) *SamplingLoggerFactory {
+	return &SamplingLoggerFactory{Factory: factory, Policies: policies}
+}
+
+// NewLogger returns a sampled LeveledLogger for the given scope
+func (f *SamplingLoggerFactory) NewLogger(scope string) LeveledLogger {
+	f.once.Do(func() {
+		f.counters = make(map[LogLevel]*[samplingCounters]samplingCounter, len(f.Policies))
+		for level := range f.Policies {
+			f.counters[level] = new([samplingCounters]samplingCounter)
+		}
+	})
+	logger := f.Factory.NewLogger(scope)
+	skipCallers(logger, 1)
+	return &samplingLogger{logger: logger, scope: scope, factory: f}
+}
+
+func (f *SamplingLoggerFactory) sample(level LogLevel, scope, msg string) bool {
+	policy, found := f.Policies[level]
+	if !found {
+		return true
+	}
+	counters, found := f.counters[level]
+	if !found {
+		return true
+	}
+
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(scope))
+	_, _ = hash.Write([]byte{0})
+	_, _ = hash.Write([]byte(msg))
+
+	n := counters[hash.Sum32()%samplingCounters].inc(time.Now(), policy.Interval)
+	if n <= policy.First {
+		return true
+	}
+	return policy.Thereafter > 0 && (n-policy.First)%policy.Thereafter == 0
+}
+
+type samplingCounter struct {
+	resetAt int64
+	count   uint64
+}
+
+// inc counts an occurrence and returns the number of occurrences in the
+// current interval
+func (c *samplingCounter) inc(now time.Time, interval time.Duration) uint64 {
+	nanos := now.UnixNano()
+	resetAt := atomic.LoadInt64(&c.resetAt)
+	if resetAt > nanos {
+		return atomic.AddUint64(&c.count, 1)
+	}
+
+	atomic.StoreUint64(&c.count, 1)
+	if !atomic.CompareAndSwapInt64(&c.resetAt, resetAt, nanos+int64(interval)) {
+		// Another goroutine started the new interval first
+		return atomic.AddUint64(&c.count, 1)
+	}
+	return 1
+}
+
+type samplingLogger struct {
+	logger  LeveledLogger
+	scope   string
+	factory *SamplingLoggerFactory
+}
+
+func (l *samplingLogger) addCallerSkip(skip int) {
+	skipCallers(l.logger, skip)
+}
+
+func (l *samplingLogger) Trace(msg string) {
+	if l.factory.sample(LogLevelTrace, l.scope, msg) {
+		l.logger.Trace(msg)
+	}
+}
+
+func (l *samplingLogger) Tracef(format string, args ...interface{}) {
+	if l.factory.sample(LogLevelTrace, l.scope, format) {
+		l.logger.Tracef(format, args...)
+	}
+}
+
+func (l *samplingLogger) Debug(msg string) {
+	if l.factory.sample(LogLevelDebug, l.scope, msg) {
+		l.logger.Debug(msg)
+	}
+}
+
+func (l *samplingLogger) Debugf(format string, args ...interface{}) {
+	if l.factory.sample(LogLevelDebug, l.scope, format) {
+		l.logger.Debugf(format, args...)
+	}
+}
+
+func (l *samplingLogger) Info(msg string) {
+	if l.factory.sample(LogLevelInfo, l.scope, msg) {
+		l.logger.Info(msg)
+	}
+}
+
+func (l *samplingLogger) Infof(format string, args ...interface{}) {
+	if l.factory.sample(LogLevelInfo, l.scope, format) {
+		l.logger.Infof(format, args...)
+	}
+}
+
+func (l *samplingLogger) Warn(msg string) {
+	if l.factory.sample(LogLevelWarn, l.scope, msg) {
+		l.logger.Warn(msg)
+	}
+}
+
+func (l *samplingLogger) Warnf(format string, args ...interface{}) {
+	if l.factory.sample(LogLevelWarn, l.scope, format) {
+		l.logger.Warnf(format, args...)
+	}
+}
+
+func (l *samplingLogger) Error(msg string) {
+	if l.factory.sample(LogLevelError, l.scope, msg) {
+		l.logger.Error(msg)
+	}
+}
+
+func (l *samplingLogger) Errorf(format string, args ...interface{}) {
+	if l.factory.sample(LogLevelError, l.scope, format) {
+		l.logger.Errorf(format, args...)
+	}
+}
@@ -0,0 +1,87 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package logging_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pion/logging"
+)
+
+func TestSamplingLoggerFactory(t *testing.T) {
+	var outBuf bytes.Buffer
+	f := logging.NewSamplingLoggerFactory(&logging.DefaultLoggerFactory{
+		Writer:          &outBuf,
+		DefaultLogLevel: logging.LogLevelTrace,
+	}, map[logging.LogLevel]logging.SamplingPolicy{
+		logging.LogLevelTrace: {Interval: time.Hour, First: 10, Thereafter: 100},
+		logging.LogLevelDebug: {Interval: time.Hour, First: 1},
+	})
+
+	ice := f.NewLogger("ice")
+	dtls := f.NewLogger("dtls")
+	for i := 0; i < 1000; i++ {
+		ice.Tracef("packet %d", i)
+		ice.Debug("keepalive")
+		dtls.Debug("keepalive")
+		ice.Info("unsampled")
+	}
+
+	out := outBuf.String()
+	// 10 first occurrences, then the 110th, 210th, ... 1000th
+	if count := strings.Count(out, "ice TRACE"); count != 19 {
+		t.Errorf("Expected 19 sampled trace records, got %d", count)
+	}
+	if !strings.Contains(out, "packet 109\n") || strings.Contains(out, "packet 10\n") {
+		t.Error("Unexpected trace records were sampled")
+	}
+	if count := strings.Count(out, "ice DEBUG"); count != 1 {
+		t.Errorf("Expected 1 debug record for ice, got %d", count)
+	}
+	if count := strings.Count(out, "dtls DEBUG"); count != 1 {
+		t.Errorf("Expected 1 debug record for dtls, got %d", count)
+	}
+	if count := strings.Count(out, "ice INFO"); count != 1000 {
+		t.Errorf("Expected all 1000 info records, got %d", count)
+	}
+}
+
+func TestSamplingLoggerFactoryInterval(t *testing.T) {
+	var outBuf bytes.Buffer
+	f := logging.NewSamplingLoggerFactory(&logging.DefaultLoggerFactory{
+		Writer:          &outBuf,
+		DefaultLogLevel: logging.LogLevelWarn,
+	}, map[logging.LogLevel]logging.SamplingPolicy{
+		logging.LogLevelWarn: {Interval: 20 * time.Millisecond, First: 2},
+	})
+
+	logger := f.NewLogger("sctp")
+	for i := 0; i < 5; i++ {
+		logger.Warn("retransmit")
+	}
+	time.Sleep(30 * time.Millisecond)
+	for i := 0; i < 5; i++ {
+		logger.Warn("retransmit")
+	}
+
+	if count := strings.Count(outBuf.String(), "retransmit"); count != 4 {
+		t.Errorf("Expected 2 records per interval, got %d", count)
+	}
+}
+
+func TestSamplingLoggerFactoryCaller(t *testing.T) {
+	var outBuf bytes.Buffer
+	f := logging.NewSamplingLoggerFactory(&logging.DefaultLoggerFactory{
+		Writer:          &outBuf,
+		DefaultLogLevel: logging.LogLevelDebug,
+	}, nil)
+
+	f.NewLogger("ice").Debug("checking")
+	if !strings.Contains(outBuf.String(), " sampling_test.go:") {
+		t.Errorf("Expected the call site in %q", outBuf.String())
+	}
+}
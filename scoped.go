@@ -4,9 +4,15 @@
 package logging
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
 	"sync/atomic"
 )
 
+var errInvalidLogLevel = errors.New("invalid log level")
+
 // LogLevel represents the level at which the logger will emit log messages
 type LogLevel int32
 
@@ -39,6 +45,71 @@ func (ll LogLevel) String() string {
 	}
 }
 
+// ParseLevel returns the LogLevel named by s. Names are matched case
+// insensitively against the values returned by String; "disable", as in
+// PION_LOG_DISABLE, is accepted for LogLevelDisabled too.
+func ParseLevel(s string) (LogLevel, error) {
+	if strings.EqualFold(s, "disable") {
+		return LogLevelDisabled, nil
+	}
+	for level := LogLevelDisabled; level <= LogLevelTrace; level++ {
+		if strings.EqualFold(s, level.String()) {
+			return level, nil
+		}
+	}
+	return LogLevelDisabled, fmt.Errorf("%w: %q", errInvalidLogLevel, s)
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (ll LogLevel) MarshalText() ([]byte, error) {
+	if ll < LogLevelDisabled || ll > LogLevelTrace {
+		return nil, fmt.Errorf("%w: %d", errInvalidLogLevel, int32(ll))
+	}
+	return []byte(ll.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (ll *LogLevel) UnmarshalText(text []byte) error {
+	level, err := ParseLevel(string(text))
+	if err != nil {
+		return err
+	}
+	*ll = level
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, encoding the level as its name
+func (ll LogLevel) MarshalJSON() ([]byte, error) {
+	text, err := ll.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(text))
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting either a level name
+// or its numeric value
+func (ll *LogLevel) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		return ll.UnmarshalText([]byte(name))
+	}
+
+	var value int32
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("%w: %s", errInvalidLogLevel, data)
+	}
+	if level := LogLevel(value); level >= LogLevelDisabled && level <= LogLevelTrace {
+		*ll = level
+		return nil
+	}
+	return fmt.Errorf("%w: %d", errInvalidLogLevel, value)
+}
+
 const (
 	// LogLevelDisabled completely disables logging of any events
 	LogLevelDisabled LogLevel = iota
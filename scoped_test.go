@@ -0,0 +1,114 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package logging_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/pion/logging"
+)
+
+func TestParseLevel(t *testing.T) {
+	for _, test := range []struct {
+		name  string
+		level logging.LogLevel
+	}{
+		{"disabled", logging.LogLevelDisabled},
+		{"disable", logging.LogLevelDisabled},
+		{"DISABLE", logging.LogLevelDisabled},
+		{"error", logging.LogLevelError},
+		{"warn", logging.LogLevelWarn},
+		{"info", logging.LogLevelInfo},
+		{"debug", logging.LogLevelDebug},
+		{"trace", logging.LogLevelTrace},
+		{"Trace", logging.LogLevelTrace},
+		{"DeBuG", logging.LogLevelDebug},
+		{"WARN", logging.LogLevelWarn},
+	} {
+		level, err := logging.ParseLevel(test.name)
+		if err != nil {
+			t.Errorf("Unexpected error parsing %q: %v", test.name, err)
+		} else if level != test.level {
+			t.Errorf("Expected %q to parse as %s, got %s", test.name, test.level, level)
+		}
+	}
+
+	for _, name := range []string{"", "UNKNOWN", "verbose", "warning"} {
+		if _, err := logging.ParseLevel(name); err == nil {
+			t.Errorf("Expected an error parsing %q", name)
+		}
+	}
+}
+
+func TestLogLevelText(t *testing.T) {
+	for level := logging.LogLevelDisabled; level <= logging.LogLevelTrace; level++ {
+		text, err := level.MarshalText()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var parsed logging.LogLevel
+		if err = parsed.UnmarshalText(text); err != nil {
+			t.Fatal(err)
+		}
+		if parsed != level {
+			t.Errorf("Expected %s to round trip, got %s", level, parsed)
+		}
+	}
+
+	if _, err := logging.LogLevel(42).MarshalText(); err == nil {
+		t.Error("Expected an error marshaling an unknown level")
+	}
+
+	parsed := logging.LogLevelInfo
+	if err := parsed.UnmarshalText([]byte("UNKNOWN")); err == nil {
+		t.Error("Expected an error unmarshaling an unknown level")
+	}
+	if parsed != logging.LogLevelInfo {
+		t.Errorf("Expected a failed unmarshal to keep %s, got %s", logging.LogLevelInfo, parsed)
+	}
+}
+
+func TestLogLevelJSON(t *testing.T) {
+	type config struct {
+		Level logging.LogLevel `json:"level"`
+	}
+
+	for level := logging.LogLevelDisabled; level <= logging.LogLevelTrace; level++ {
+		data, err := json.Marshal(config{Level: level})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if expected := fmt.Sprintf(`{"level":%q}`, level.String()); string(data) != expected {
+			t.Errorf("Expected %s, got %s", expected, data)
+		}
+
+		var decoded config
+		if err = json.Unmarshal(data, &decoded); err != nil {
+			t.Fatal(err)
+		}
+		if decoded.Level != level {
+			t.Errorf("Expected %s to round trip, got %s", level, decoded.Level)
+		}
+	}
+
+	var decoded config
+	if err := json.Unmarshal([]byte(`{"level":4}`), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Level != logging.LogLevelDebug {
+		t.Errorf("Expected numeric level to decode as %s, got %s", logging.LogLevelDebug, decoded.Level)
+	}
+
+	for _, data := range []string{`{"level":"verbose"}`, `{"level":9}`, `{"level":-1}`, `{"level":true}`} {
+		if err := json.Unmarshal([]byte(data), &decoded); err == nil {
+			t.Errorf("Expected an error decoding %s", data)
+		}
+	}
+	if _, err := json.Marshal(config{Level: 42}); err == nil {
+		t.Error("Expected an error marshaling an unknown level")
+	}
+}
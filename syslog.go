@@ -0,0 +1,171 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package logging
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Syslog severities used when mapping a LogLevel, as defined by RFC 5424
+const (
+	syslogSeverityErr     = 3
+	syslogSeverityWarning = 4
+	syslogSeverityInfo    = 6
+	syslogSeverityDebug   = 7
+)
+
+const syslogNilValue = "-"
+
+// SyslogWriter ships log records to a syslog server as RFC 5424 messages.
+// Records are written through the io.Writer returned by LevelWriter, which
+// determines the severity of the message.
+type SyslogWriter struct {
+	// Facility is the syslog facility code, e.g. 1 for user-level messages
+	// or 16 for local0
+	Facility int
+	// Hostname is reported in the HOSTNAME header field
+	Hostname string
+	// AppName is reported in the APP-NAME header field
+	AppName string
+
+	mu     sync.Mutex
+	conn   net.Conn
+	stream bool
+	procID string
+}
+
+// NewSyslogWriter connects to the syslog server at address. network may be a
+// datagram network such as "udp" or "unixgram", or a stream network such as
+// "tcp" or "unix", in which case messages are framed with octet counting as
+// described by RFC 6587. An empty hostname or appName is replaced with the
+// local hostname and the program name.
+func NewSyslogWriter(network, address string, facility int, hostname, appName string) (*SyslogWriter, error) {
+	conn, err := net.Dial(network, address)
+	if err != nil {
+		return nil, err
+	}
+
+	if hostname == "" {
+		if hostname, err = os.Hostname(); err != nil {
+			hostname = syslogNilValue
+		}
+	}
+	if appName == "" {
+		appName = filepath.Base(os.Args[0])
+	}
+
+	return &SyslogWriter{
+		Facility: facility,
+		Hostname: hostname,
+		AppName:  appName,
+		conn:     conn,
+		stream:   strings.HasPrefix(network, "tcp") || network == "unix",
+		procID:   strconv.Itoa(os.Getpid()),
+	}, nil
+}
+
+// LevelWriter returns an io.Writer that sends every write as one syslog
+// message with the severity mapped from level
+func (w *SyslogWriter) LevelWriter(level LogLevel) io.Writer {
+	return &syslogLevelWriter{writer: w, severity: syslogSeverity(level)}
+}
+
+// Close closes the connection to the syslog server
+func (w *SyslogWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.conn.Close()
+}
+
+func (w *SyslogWriter) writeMessage(severity int, msg []byte) error {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "<%d>1 %s %s %s %s %s %s ",
+		w.Facility*8+severity,
+		time.Now().Format("2006-01-02T15:04:05.000000Z07:00"),
+		syslogHeaderField(w.Hostname),
+		syslogHeaderField(w.AppName),
+		w.procID,
+		syslogNilValue, // MSGID
+		syslogNilValue, // STRUCTURED-DATA
+	)
+	buf.Write(bytes.TrimRight(msg, "\n"))
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.stream {
+		if _, err := fmt.Fprintf(w.conn, "%d ", buf.Len()); err != nil {
+			return err
+		}
+	}
+	_, err := w.conn.Write(buf.Bytes())
+	return err
+}
+
+type syslogLevelWriter struct {
+	writer   *SyslogWriter
+	severity int
+}
+
+func (lw *syslogLevelWriter) Write(data []byte) (int, error) {
+	if err := lw.writer.writeMessage(lw.severity, data); err != nil {
+		return 0, err
+	}
+	return len(data), nil
+}
+
+// NewSyslogLeveledLoggerForScope returns a DefaultLeveledLogger that sends
+// every level to w with the matching syslog severity
+func NewSyslogLeveledLoggerForScope(scope string, level LogLevel, w *SyslogWriter) *DefaultLeveledLogger {
+	prefix := fmt.Sprintf("%s: ", scope)
+	return NewDefaultLeveledLoggerForScope(scope, level, io.Discard).
+		WithTraceLogger(log.New(w.LevelWriter(LogLevelTrace), prefix, 0)).
+		WithDebugLogger(log.New(w.LevelWriter(LogLevelDebug), prefix, 0)).
+		WithInfoLogger(log.New(w.LevelWriter(LogLevelInfo), prefix, 0)).
+		WithWarnLogger(log.New(w.LevelWriter(LogLevelWarn), prefix, 0)).
+		WithErrorLogger(log.New(w.LevelWriter(LogLevelError), prefix, 0))
+}
+
+func syslogSeverity(level LogLevel) int {
+	switch level {
+	case LogLevelError:
+		return syslogSeverityErr
+	case LogLevelWarn:
+		return syslogSeverityWarning
+	case LogLevelInfo:
+		return syslogSeverityInfo
+	default:
+		return syslogSeverityDebug
+	}
+}
+
+// syslogHeaderField replaces an empty header value with NILVALUE and strips
+// characters that are not allowed in header fields
+func syslogHeaderField(value string) string {
+	if value == "" {
+		return syslogNilValue
+	}
+
+	field := []byte(value)
+	n := 0
+	for _, c := range field {
+		if c > 32 && c < 127 {
+			field[n] = c
+			n++
+		}
+	}
+	if n == 0 {
+		return syslogNilValue
+	}
+	return string(field[:n])
+}
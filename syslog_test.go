@@ -0,0 +1,144 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package logging_test
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pion/logging"
+)
+
+func readDatagram(t *testing.T, conn net.PacketConn) string {
+	t.Helper()
+
+	buf := make([]byte, 2048)
+	if err := conn.SetReadDeadline(time.Now().Add(5 * time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	n, _, err := conn.ReadFrom(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(buf[:n])
+}
+
+func TestSyslogWriterUDP(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	writer, err := logging.NewSyslogWriter("udp", conn.LocalAddr().String(), 16, "host1", "sfu")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = writer.Close() }()
+
+	logger := logging.NewSyslogLeveledLoggerForScope("ice", logging.LogLevelTrace, writer)
+
+	for _, test := range []struct {
+		log      func(string)
+		priority string
+	}{
+		{logger.Error, "<131>1 "},
+		{logger.Warn, "<132>1 "},
+		{logger.Info, "<134>1 "},
+		{logger.Debug, "<135>1 "},
+		{logger.Trace, "<135>1 "},
+	} {
+		test.log("candidate gathered")
+		msg := readDatagram(t, conn)
+
+		if !strings.HasPrefix(msg, test.priority) {
+			t.Errorf("Expected %q to start with %q", msg, test.priority)
+		}
+		fields := strings.SplitN(msg, " ", 8)
+		if len(fields) != 8 {
+			t.Fatalf("Malformed syslog message %q", msg)
+		}
+		if _, err := time.Parse(time.RFC3339Nano, fields[1]); err != nil {
+			t.Errorf("Invalid timestamp in %q: %v", msg, err)
+		}
+		if fields[2] != "host1" || fields[3] != "sfu" {
+			t.Errorf("Unexpected hostname or app name in %q", msg)
+		}
+		if fields[5] != "-" || fields[6] != "-" {
+			t.Errorf("Expected nil MSGID and STRUCTURED-DATA in %q", msg)
+		}
+		if fields[7] != "ice: candidate gathered" {
+			t.Errorf("Unexpected message %q", fields[7])
+		}
+	}
+}
+
+func TestSyslogWriterTCP(t *testing.T) {
+	testSyslogWriterStream(t, "tcp", "127.0.0.1:0")
+}
+
+func TestSyslogWriterUnix(t *testing.T) {
+	dir, err := os.MkdirTemp("", "syslog")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	testSyslogWriterStream(t, "unix", filepath.Join(dir, "log.sock"))
+}
+
+func testSyslogWriterStream(t *testing.T, network, address string) {
+	t.Helper()
+
+	listener, err := net.Listen(network, address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = listener.Close() }()
+
+	writer, err := logging.NewSyslogWriter(network, listener.Addr().String(), 1, "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = writer.Close() }()
+
+	conn, err := listener.Accept()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	for _, msg := range []string{"hello\n", "world\n"} {
+		if _, err = writer.LevelWriter(logging.LogLevelWarn).Write([]byte(msg)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	reader := bufio.NewReader(conn)
+	for _, expected := range []string{" hello", " world"} {
+		length, err := reader.ReadString(' ')
+		if err != nil {
+			t.Fatal(err)
+		}
+		size, err := strconv.Atoi(strings.TrimSpace(length))
+		if err != nil {
+			t.Fatalf("Expected an octet count, got %q", length)
+		}
+		msg := make([]byte, size)
+		if _, err = io.ReadFull(reader, msg); err != nil {
+			t.Fatal(err)
+		}
+		line := string(msg)
+		if !strings.HasPrefix(line, "<12>1 ") || !strings.HasSuffix(line, expected) {
+			t.Errorf("Unexpected framed message %q", line)
+		}
+	}
+}
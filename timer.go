@@ -0,0 +1,22 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package logging
+
+// Timer starts timing an operation and returns a function which emits msg at
+// the given level with the elapsed time as a duration field, followed by the
+// key/value pairs in kv. If the level is disabled when Timer is called, the
+// time isn't read and the returned function does nothing.
+func (ll *DefaultLeveledLogger) Timer(level LogLevel, msg string) func(kv ...interface{}) {
+	logger := ll.loggerForLevel(level)
+	if logger == nil || !ll.Enabled(level) {
+		return func(...interface{}) {}
+	}
+
+	start := ll.now()
+	return func(kv ...interface{}) {
+		fields := make([]interface{}, 0, len(kv)+2)
+		fields = append(fields, "duration", ll.now().Sub(start))
+		ll.logKV(logger, level, msg, append(fields, kv...))
+	}
+}
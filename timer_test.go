@@ -0,0 +1,44 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package logging_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pion/logging"
+)
+
+func TestTimer(t *testing.T) {
+	var outBuf bytes.Buffer
+	now := time.Unix(1700000000, 0)
+	reads := 0
+	logger := logging.
+		NewDefaultLeveledLoggerForScope("testTimer", logging.LogLevelInfo, &outBuf).
+		WithTimestamp("").
+		WithCaller(true, 0).
+		WithClock(func() time.Time {
+			reads++
+			return now
+		})
+
+	stop := logger.Timer(logging.LogLevelInfo, "handshake")
+	now = now.Add(1500 * time.Millisecond)
+	stop("peer", "10.0.0.1")
+
+	out := outBuf.String()
+	if !strings.HasPrefix(out, "testTimer INFO: timer_test.go:") ||
+		!strings.HasSuffix(out, " handshake duration=1.5s peer=10.0.0.1\n") {
+		t.Errorf("Unexpected timer record %q", out)
+	}
+
+	outBuf.Reset()
+	reads = 0
+	logger.Timer(logging.LogLevelDebug, "filtered")()
+	if outBuf.Len() > 0 || reads != 0 {
+		t.Errorf("Expected a disabled timer to skip the clock, got %d reads and %q", reads, outBuf.String())
+	}
+}
@@ -0,0 +1,146 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package logging
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sync"
+	"time"
+)
+
+// traceMagic starts every binary trace stream, followed by a version byte
+const (
+	traceMagic   = "PTRC"
+	traceVersion = 1
+)
+
+var errInvalidTraceHeader = errors.New("invalid binary trace header")
+
+// TraceEntry is a single wire-level trace record
+type TraceEntry struct {
+	Time    time.Time
+	ScopeID uint16
+	Code    uint16
+	Data    []byte
+}
+
+// TraceBinaryWriter encodes trace entries in a compact binary framing meant
+// for offline decoding with DecodeTrace. Each entry stores the time elapsed
+// since the previous one, the scope id, the event code and the raw bytes as
+// varints and a length prefixed payload. Entries are only written while the
+// writer's level is LogLevelTrace.
+type TraceBinaryWriter struct {
+	level LogLevel
+
+	mu      sync.Mutex
+	writer  io.Writer
+	started bool
+	last    int64
+	buf     []byte
+}
+
+// NewTraceBinaryWriter returns a TraceBinaryWriter writing to w at the given level
+func NewTraceBinaryWriter(w io.Writer, level LogLevel) *TraceBinaryWriter {
+	return &TraceBinaryWriter{writer: w, level: level}
+}
+
+// SetLevel sets the writer's logging level
+func (w *TraceBinaryWriter) SetLevel(newLevel LogLevel) {
+	w.level.Set(newLevel)
+}
+
+// WriteTrace encodes entry if the writer is at LogLevelTrace. A zero Time is
+// replaced with the current time.
+func (w *TraceBinaryWriter) WriteTrace(entry TraceEntry) error {
+	if w.level.Get() < LogLevelTrace {
+		return nil
+	}
+	if entry.Time.IsZero() {
+		entry.Time = time.Now()
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	buf := w.buf[:0]
+	now := entry.Time.UnixNano()
+	if !w.started {
+		buf = append(buf, traceMagic...)
+		buf = append(buf, traceVersion)
+		buf = binary.AppendVarint(buf, now)
+		w.last = now
+	}
+	buf = binary.AppendVarint(buf, now-w.last)
+	buf = binary.AppendUvarint(buf, uint64(entry.ScopeID))
+	buf = binary.AppendUvarint(buf, uint64(entry.Code))
+	buf = binary.AppendUvarint(buf, uint64(len(entry.Data)))
+	buf = append(buf, entry.Data...)
+	w.buf = buf
+
+	if _, err := w.writer.Write(buf); err != nil {
+		return err
+	}
+	w.started = true
+	w.last = now
+	return nil
+}
+
+// DecodeTrace decodes all entries written by a TraceBinaryWriter
+func DecodeTrace(r io.Reader) ([]TraceEntry, error) {
+	reader := bufio.NewReader(r)
+
+	header := make([]byte, len(traceMagic)+1)
+	if _, err := io.ReadFull(reader, header); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if string(header[:len(traceMagic)]) != traceMagic || header[len(traceMagic)] != traceVersion {
+		return nil, errInvalidTraceHeader
+	}
+	last, err := binary.ReadVarint(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []TraceEntry
+	for {
+		delta, err := binary.ReadVarint(reader)
+		if errors.Is(err, io.EOF) {
+			return entries, nil
+		} else if err != nil {
+			return entries, err
+		}
+
+		var fields [3]uint64
+		for i := range fields {
+			if fields[i], err = binary.ReadUvarint(reader); err != nil {
+				return entries, fmt.Errorf("truncated trace entry: %w", io.ErrUnexpectedEOF)
+			}
+		}
+		// copy rather than allocate the length up front, it may be corrupt
+		var data bytes.Buffer
+		if fields[2] > math.MaxInt64 {
+			return entries, fmt.Errorf("truncated trace entry: %w", io.ErrUnexpectedEOF)
+		}
+		if _, err = io.CopyN(&data, reader, int64(fields[2])); err != nil {
+			return entries, fmt.Errorf("truncated trace entry: %w", io.ErrUnexpectedEOF)
+		}
+
+		last += delta
+		entries = append(entries, TraceEntry{
+			Time:    time.Unix(0, last),
+			ScopeID: uint16(fields[0]),
+			Code:    uint16(fields[1]),
+			Data:    data.Bytes(),
+		})
+	}
+}
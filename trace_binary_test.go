@@ -0,0 +1,97 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package logging_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/pion/logging"
+)
+
+func TestTraceBinaryRoundTrip(t *testing.T) {
+	var outBuf bytes.Buffer
+	writer := logging.NewTraceBinaryWriter(&outBuf, logging.LogLevelTrace)
+
+	base := time.Unix(1700000000, 123456789)
+	entries := []logging.TraceEntry{
+		{Time: base, ScopeID: 1, Code: 7, Data: []byte{0x80, 0x60, 0x00, 0x01}},
+		{Time: base.Add(1500 * time.Microsecond), ScopeID: 2, Code: 300, Data: nil},
+		{Time: base.Add(time.Hour), ScopeID: 65535, Code: 65535, Data: bytes.Repeat([]byte{0xff}, 1500)},
+		{Time: base.Add(time.Second), ScopeID: 1, Code: 8, Data: []byte("late")},
+	}
+	for _, entry := range entries {
+		if err := writer.WriteTrace(entry); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	decoded, err := logging.DecodeTrace(&outBuf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(decoded) != len(entries) {
+		t.Fatalf("Expected %d entries, got %d", len(entries), len(decoded))
+	}
+	for i, entry := range entries {
+		got := decoded[i]
+		if !got.Time.Equal(entry.Time) || got.ScopeID != entry.ScopeID || got.Code != entry.Code ||
+			!bytes.Equal(got.Data, entry.Data) {
+			t.Errorf("Entry %d: expected %+v, got %+v", i, entry, got)
+		}
+	}
+}
+
+func TestTraceBinaryWriterLevel(t *testing.T) {
+	var outBuf bytes.Buffer
+	writer := logging.NewTraceBinaryWriter(&outBuf, logging.LogLevelDebug)
+
+	if err := writer.WriteTrace(logging.TraceEntry{Code: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if outBuf.Len() > 0 {
+		t.Error("Trace entry was written below LogLevelTrace")
+	}
+
+	writer.SetLevel(logging.LogLevelTrace)
+	if err := writer.WriteTrace(logging.TraceEntry{Code: 2}); err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := logging.DecodeTrace(&outBuf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(decoded) != 1 || decoded[0].Code != 2 || decoded[0].Time.IsZero() {
+		t.Errorf("Unexpected entries %+v", decoded)
+	}
+}
+
+func TestDecodeTraceErrors(t *testing.T) {
+	if _, err := logging.DecodeTrace(bytes.NewReader([]byte("JUNK\x01\x00"))); err == nil {
+		t.Error("Expected an error for an invalid header")
+	}
+
+	var outBuf bytes.Buffer
+	writer := logging.NewTraceBinaryWriter(&outBuf, logging.LogLevelTrace)
+	if err := writer.WriteTrace(logging.TraceEntry{Data: []byte("payload")}); err != nil {
+		t.Fatal(err)
+	}
+	truncated := outBuf.Bytes()[:outBuf.Len()-2]
+	if _, err := logging.DecodeTrace(bytes.NewReader(truncated)); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("Expected %v for a truncated entry, got %v", io.ErrUnexpectedEOF, err)
+	}
+
+	// header, base time, delta, scope, code and a corrupt payload length
+	for _, length := range []uint64{math.MaxUint64, 1 << 40} {
+		corrupt := binary.AppendUvarint([]byte("PTRC\x01\x00\x00\x01\x01"), length)
+		if _, err := logging.DecodeTrace(bytes.NewReader(corrupt)); !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Errorf("Expected %v for payload length %d, got %v", io.ErrUnexpectedEOF, length, err)
+		}
+	}
+}